@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newApplyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "apply",
+		Short: "Write the configured flags into each browser's .desktop entry",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := loadConfigOrExit()
+			journal, err := openJournalOrExit()
+			if err != nil {
+				return err
+			}
+			updated := 0
+
+			err = forEachCandidate(config, func(browser BrowserConfig, filename string, result FileResult) {
+				if result.Action == ActionWillModify {
+					if _, err := journal.RecordApply(filename, result.OriginalContent, result.NewContent, browser.FlagSets); err != nil {
+						fmt.Printf("⚠️  Warning: could not record state for %s: %v\n", filename, err)
+						return
+					}
+				}
+				if err := result.Write(); err != nil {
+					fmt.Printf("⚠️  Warning: %v\n", err)
+					return
+				}
+				switch result.Action {
+				case ActionWillModify:
+					updated++
+					if !opts.Quiet {
+						fmt.Printf("✅ Updated %s (%d Exec lines modified)\n", filename, result.ModifiedCount)
+					}
+				case ActionWillCopyOnly:
+					if opts.Verbose {
+						fmt.Printf("📋 Copied %s to user directory\n", filename)
+					}
+				case ActionUpToDate:
+					if opts.Verbose {
+						fmt.Printf("✅ Up-to-date: %s\n", filename)
+					}
+				}
+			})
+			if err != nil {
+				return err
+			}
+
+			if !opts.Quiet {
+				fmt.Printf("\n🎉 Desktop entry management completed (%d file(s) updated)\n", updated)
+			}
+			return nil
+		},
+	}
+}
+
+func newDryRunCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "dry-run",
+		Short: "Show what apply would change, without touching disk",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := loadConfigOrExit()
+			changed := 0
+
+			err := forEachCandidate(config, func(browser BrowserConfig, filename string, result FileResult) {
+				if result.Action != ActionWillModify {
+					if opts.Verbose {
+						fmt.Printf("✅ Up-to-date: %s\n", filename)
+					}
+					return
+				}
+				changed++
+				fmt.Print(result.Diff)
+			})
+			if err != nil {
+				return err
+			}
+
+			if !opts.Quiet {
+				fmt.Printf("\n%d file(s) would be changed\n", changed)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+const (
+	configActionToggleBrowsers = "Enable/disable browsers"
+	configActionEditFlagSets   = "Toggle flag sets for a browser"
+	configActionNewFlagSet     = "Create a new flag set"
+	configActionSave           = "Save and exit"
+	configActionDiscard        = "Exit without saving"
+	configActionDone           = "Done"
+)
+
+func newConfigCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "config",
+		Short: "Interactively edit config.json",
+		Long: "Opens an interactive prompt for enabling or disabling browsers,\n" +
+			"toggling which flag sets apply to each one, and defining new flag\n" +
+			"sets — without hand-editing config.json. Nothing is written until\n" +
+			"you choose \"Save and exit\"; the file is then replaced atomically\n" +
+			"(temp file + rename), keeping the same pretty-printed encoding\n" +
+			"loadConfiguration writes for a fresh default.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configPath := opts.ConfigPath
+			if configPath == "" {
+				path, err := defaultConfigPath()
+				if err != nil {
+					return err
+				}
+				configPath = path
+			}
+			config, err := loadConfigFileOrDefault(configPath)
+			if err != nil {
+				return err
+			}
+			return runConfigEditor(configPath, config)
+		},
+	}
+}
+
+// loadConfigFileOrDefault reads configPath directly, skipping the /etc
+// defaults and config.d/flagsets.d/browsers.d drop-ins loadConfiguration
+// layers in — those live in their own files, so the editor only ever
+// reads and rewrites the one file it's pointed at.
+func loadConfigFileOrDefault(configPath string) (AppConfig, error) {
+	raw, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return getDefaultConfig(), nil
+	}
+	if err != nil {
+		return AppConfig{}, fmt.Errorf("could not read config file: %w", err)
+	}
+	var config AppConfig
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return AppConfig{}, fmt.Errorf("could not parse config file: %w", err)
+	}
+	return config, nil
+}
+
+func runConfigEditor(configPath string, config AppConfig) error {
+	for {
+		menu := promptui.Select{
+			Label: "wailandify config",
+			Items: []string{
+				configActionToggleBrowsers,
+				configActionEditFlagSets,
+				configActionNewFlagSet,
+				configActionSave,
+				configActionDiscard,
+			},
+		}
+		_, choice, err := menu.Run()
+		if err != nil {
+			return fmt.Errorf("prompt cancelled: %w", err)
+		}
+
+		switch choice {
+		case configActionToggleBrowsers:
+			if err := toggleBrowsers(&config); err != nil {
+				return err
+			}
+		case configActionEditFlagSets:
+			if err := editBrowserFlagSets(&config); err != nil {
+				return err
+			}
+		case configActionNewFlagSet:
+			if err := createFlagSet(&config); err != nil {
+				return err
+			}
+		case configActionSave:
+			return writeConfigAtomic(configPath, config)
+		case configActionDiscard:
+			fmt.Println("Discarded changes.")
+			return nil
+		}
+	}
+}
+
+// toggleBrowsers lets the user flip membership of config.Browsers against
+// browserCatalog, one entry at a time, until they pick Done.
+func toggleBrowsers(config *AppConfig) error {
+	catalog := browserCatalog(*config)
+	for {
+		items := make([]string, 0, len(catalog)+1)
+		for _, name := range catalog {
+			items = append(items, fmt.Sprintf("[%s] %s", checkMark(browserEnabled(*config, name)), name))
+		}
+		items = append(items, configActionDone)
+
+		prompt := promptui.Select{Label: "Toggle browsers (Enter to flip, Done to finish)", Items: items}
+		idx, _, err := prompt.Run()
+		if err != nil {
+			return fmt.Errorf("prompt cancelled: %w", err)
+		}
+		if idx == len(catalog) {
+			return nil
+		}
+		toggleBrowser(config, catalog[idx])
+	}
+}
+
+// browserCatalog returns every browser name this tool knows how to manage —
+// the hardcoded defaults plus any already present in config — so toggling
+// never has to invent a new BrowserConfig from scratch.
+func browserCatalog(config AppConfig) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, browser := range getDefaultConfig().Browsers {
+		if !seen[browser.Name] {
+			seen[browser.Name] = true
+			names = append(names, browser.Name)
+		}
+	}
+	for _, browser := range config.Browsers {
+		if !seen[browser.Name] {
+			seen[browser.Name] = true
+			names = append(names, browser.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func browserEnabled(config AppConfig, name string) bool {
+	for _, browser := range config.Browsers {
+		if browser.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// toggleBrowser adds or removes name from config.Browsers, pulling its
+// definition from the hardcoded defaults the first time it's enabled.
+func toggleBrowser(config *AppConfig, name string) {
+	for i, browser := range config.Browsers {
+		if browser.Name == name {
+			config.Browsers = append(config.Browsers[:i], config.Browsers[i+1:]...)
+			return
+		}
+	}
+	for _, browser := range getDefaultConfig().Browsers {
+		if browser.Name == name {
+			config.Browsers = append(config.Browsers, browser)
+			return
+		}
+	}
+}
+
+// editBrowserFlagSets lets the user pick one of config.Browsers and then
+// toggle which of config.FlagSets it applies, until they pick Done.
+func editBrowserFlagSets(config *AppConfig) error {
+	if len(config.Browsers) == 0 {
+		fmt.Println("No browsers enabled yet — enable one first.")
+		return nil
+	}
+
+	names := make([]string, len(config.Browsers))
+	for i, browser := range config.Browsers {
+		names[i] = browser.Name
+	}
+	pickBrowser := promptui.Select{Label: "Edit flag sets for", Items: names}
+	browserIdx, _, err := pickBrowser.Run()
+	if err != nil {
+		return fmt.Errorf("prompt cancelled: %w", err)
+	}
+	browser := &config.Browsers[browserIdx]
+
+	keys := make([]string, 0, len(config.FlagSets))
+	for key := range config.FlagSets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for {
+		items := make([]string, 0, len(keys)+1)
+		for _, key := range keys {
+			items = append(items, fmt.Sprintf("[%s] %s: %s", checkMark(hasFlagSet(browser.FlagSets, key)), key, config.FlagSets[key].Name))
+		}
+		items = append(items, configActionDone)
+
+		prompt := promptui.Select{
+			Label: fmt.Sprintf("Flag sets for %s (Enter to flip, Done to finish)", browser.Name),
+			Items: items,
+		}
+		idx, _, err := prompt.Run()
+		if err != nil {
+			return fmt.Errorf("prompt cancelled: %w", err)
+		}
+		if idx == len(keys) {
+			return nil
+		}
+		browser.FlagSets = toggleString(browser.FlagSets, keys[idx])
+	}
+}
+
+func hasFlagSet(flagSets []string, key string) bool {
+	for _, k := range flagSets {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func toggleString(list []string, value string) []string {
+	for i, v := range list {
+		if v == value {
+			return append(list[:i], list[i+1:]...)
+		}
+	}
+	return append(list, value)
+}
+
+func checkMark(on bool) string {
+	if on {
+		return "x"
+	}
+	return " "
+}
+
+// createFlagSet prompts for a key, display name, and comma-separated flag
+// list, then adds the result to config.FlagSets.
+func createFlagSet(config *AppConfig) error {
+	keyPrompt := promptui.Prompt{
+		Label: "Flag set key (used as flagSets.<key>, e.g. my_custom_flags)",
+		Validate: func(s string) error {
+			if s == "" {
+				return fmt.Errorf("key cannot be empty")
+			}
+			if _, exists := config.FlagSets[s]; exists {
+				return fmt.Errorf("a flag set named %q already exists", s)
+			}
+			return nil
+		},
+	}
+	key, err := keyPrompt.Run()
+	if err != nil {
+		return fmt.Errorf("prompt cancelled: %w", err)
+	}
+
+	namePrompt := promptui.Prompt{Label: "Display name"}
+	name, err := namePrompt.Run()
+	if err != nil {
+		return fmt.Errorf("prompt cancelled: %w", err)
+	}
+
+	flagsPrompt := promptui.Prompt{Label: "Flags (comma-separated, e.g. --ozone-platform=wayland,--foo)"}
+	flagsRaw, err := flagsPrompt.Run()
+	if err != nil {
+		return fmt.Errorf("prompt cancelled: %w", err)
+	}
+
+	var flags []string
+	for _, flag := range strings.Split(flagsRaw, ",") {
+		if trimmed := strings.TrimSpace(flag); trimmed != "" {
+			flags = append(flags, trimmed)
+		}
+	}
+
+	if config.FlagSets == nil {
+		config.FlagSets = map[string]FlagSet{}
+	}
+	config.FlagSets[key] = FlagSet{Name: name, Flags: flags}
+	fmt.Printf("Added flag set %q.\n", key)
+	return nil
+}
+
+// writeConfigAtomic pretty-prints config the same way loadConfiguration
+// does for a fresh default, writing it to a temp file in the same
+// directory before renaming it over configPath so a crash mid-write can
+// never leave a truncated config.json behind.
+func writeConfigAtomic(configPath string, config AppConfig) error {
+	dir := filepath.Dir(configPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("could not create config directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".config-*.json.tmp")
+	if err != nil {
+		return fmt.Errorf("could not create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	encoder := json.NewEncoder(tmp)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(config); err != nil {
+		tmp.Close()
+		return fmt.Errorf("could not write config: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("could not write config: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, configPath); err != nil {
+		return fmt.Errorf("could not replace config file: %w", err)
+	}
+	fmt.Printf("✅ Saved %s\n", configPath)
+	return nil
+}
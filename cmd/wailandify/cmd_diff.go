@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jassielof/wailandify/internal/hostenv"
+	"github.com/spf13/cobra"
+)
+
+func newDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff <file>",
+		Short: "Show what apply would change for a single .desktop file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := loadConfigOrExit()
+			filename := args[0]
+			info := hostenv.Detect()
+			session := hostenv.SessionEnv()
+
+			for _, browser := range config.Browsers {
+				if !desktopFileBelongsToBrowser(browser, filename) {
+					continue
+				}
+				combinedFlags := computeBrowserFlags(browser, config, info, session)
+				result, err := processDesktopFile(opts, filename, combinedFlags)
+				if err != nil {
+					return err
+				}
+				if result.Action == ActionSkippedNoSource {
+					continue
+				}
+				if result.Action != ActionWillModify {
+					fmt.Printf("✅ Up-to-date: %s\n", filename)
+					return nil
+				}
+				fmt.Print(result.Diff)
+				return nil
+			}
+
+			return fmt.Errorf("no browser configuration matches %s", filename)
+		},
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func newListFlagsetsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list-flagsets",
+		Short: "List the available flag sets and the flags they apply",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := loadConfigOrExit()
+
+			names := make([]string, 0, len(config.FlagSets))
+			for name := range config.FlagSets {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				flagSet := config.FlagSets[name]
+				fmt.Printf("• %s: %s\n", name, flagSet.Name)
+				for _, flag := range flagSet.Flags {
+					fmt.Printf("    %s\n", flag)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func newListBrowsersCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list-browsers",
+		Short: "List the configured browsers and the flag sets applied to each",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := loadConfigOrExit()
+
+			for _, browser := range config.Browsers {
+				fmt.Printf("• %s: %s\n", browser.Name, browser.Description)
+				fmt.Printf("    flag sets: %s\n", strings.Join(browser.FlagSets, ", "))
+				fmt.Printf("    desktop files: %s\n", strings.Join(browser.DesktopFiles, ", "))
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// newRevertCmd restores a user .desktop file from the backup the state
+// journal recorded for it. With --file it only reverts that one entry; with
+// --to it picks the latest apply at or before the given RFC3339 timestamp
+// instead of the most recent one.
+//
+// Unlike apply/dry-run, this enumerates filenames from the journal itself
+// (the same way status does) instead of forEachCandidate: forEachCandidate
+// skips a browser whenever its *currently computed* combinedFlags is empty,
+// which AppliesWhen/When (host- and session-gated flag sets) can now make
+// true even though a backup from a previous, differently-gated apply still
+// exists — e.g. a GNOME-only flag set applied under GNOME can't be reverted
+// once run again under KDE if revert first had to recompute those flags.
+func newRevertCmd() *cobra.Command {
+	var onlyFile string
+	var toTimestamp string
+
+	cmd := &cobra.Command{
+		Use:   "revert",
+		Short: "Restore browser .desktop entries from their last recorded backup",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			journal, err := openJournalOrExit()
+			if err != nil {
+				return err
+			}
+
+			cutoff := time.Now()
+			if toTimestamp != "" {
+				cutoff, err = time.Parse(time.RFC3339, toTimestamp)
+				if err != nil {
+					return fmt.Errorf("invalid --to timestamp %q (expected RFC3339): %w", toTimestamp, err)
+				}
+			}
+
+			filenames, err := trackedFilenames(journal)
+			if err != nil {
+				return err
+			}
+
+			reverted := 0
+			for _, filename := range filenames {
+				if onlyFile != "" && filename != onlyFile {
+					continue
+				}
+
+				entry, ok, err := journal.BeforeOrAt(filename, cutoff)
+				if err != nil {
+					fmt.Printf("⚠️  Warning: could not read state journal for %s: %v\n", filename, err)
+					continue
+				}
+				if !ok {
+					if opts.Verbose {
+						fmt.Printf("ℹ️  No recorded backup for %s, nothing to revert\n", filename)
+					}
+					continue
+				}
+
+				backup, err := os.ReadFile(entry.BackupPath)
+				if err != nil {
+					fmt.Printf("⚠️  Warning: could not read backup for %s: %v\n", filename, err)
+					continue
+				}
+				dstPath := filepath.Join(opts.UserDir, filename)
+				if err := os.WriteFile(dstPath, backup, 0644); err != nil {
+					fmt.Printf("⚠️  Warning: could not revert %s: %v\n", filename, err)
+					continue
+				}
+				if err := journal.RecordRevert(filename, entry.BackupPath, backup); err != nil {
+					fmt.Printf("⚠️  Warning: could not record revert for %s: %v\n", filename, err)
+				}
+
+				reverted++
+				if !opts.Quiet {
+					fmt.Printf("⏪ Reverted %s to backup from %s\n", filename, entry.Timestamp.Format(time.RFC3339))
+				}
+			}
+
+			if !opts.Quiet {
+				fmt.Printf("\n%d file(s) reverted\n", reverted)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&onlyFile, "file", "", "only revert this .desktop filename")
+	cmd.Flags().StringVar(&toTimestamp, "to", "", "revert to the backup at or before this RFC3339 timestamp (default: latest)")
+	return cmd
+}
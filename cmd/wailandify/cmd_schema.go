@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jassielof/wailandify/internal/schema"
+	"github.com/spf13/cobra"
+)
+
+func newSchemaCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema for config.json",
+		Long: "Prints the draft 2020-12 JSON Schema wailandify validates config.json\n" +
+			"against, so an editor can be pointed at a local copy via \"$schema\" for\n" +
+			"autocompletion.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Print(schema.JSON)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jassielof/wailandify/internal/state"
+	"github.com/spf13/cobra"
+)
+
+// trackedFilenames returns every filename the journal has ever recorded an
+// entry for, oldest-first, deduplicated. Both status and revert enumerate
+// files this way rather than via forEachCandidate, since a file can still
+// have a backup worth acting on even when the config's currently computed
+// flags for it are empty (e.g. a host/session-gated flag set that no longer
+// matches).
+func trackedFilenames(journal *state.Journal) ([]string, error) {
+	entries, err := journal.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	var seen []string
+	seenSet := make(map[string]bool)
+	for _, entry := range entries {
+		if !seenSet[entry.Filename] {
+			seenSet[entry.Filename] = true
+			seen = append(seen, entry.Filename)
+		}
+	}
+	return seen, nil
+}
+
+// newStatusCmd lists every file the state journal has ever touched and
+// whether it is currently up-to-date, reverted, or hand-edited since.
+func newStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show which tracked .desktop files are modified, up-to-date, or drifted",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			journal, err := openJournalOrExit()
+			if err != nil {
+				return err
+			}
+
+			seen, err := trackedFilenames(journal)
+			if err != nil {
+				return err
+			}
+
+			if len(seen) == 0 {
+				fmt.Println("No files are tracked yet — run `wailandify apply` first.")
+				return nil
+			}
+
+			for _, filename := range seen {
+				current, err := os.ReadFile(filepath.Join(opts.UserDir, filename))
+				if os.IsNotExist(err) {
+					fmt.Printf("• %s: missing\n", filename)
+					continue
+				}
+				if err != nil {
+					fmt.Printf("• %s: error reading file: %v\n", filename, err)
+					continue
+				}
+
+				drift, _, err := journal.Classify(filename, current)
+				if err != nil {
+					fmt.Printf("• %s: error classifying: %v\n", filename, err)
+					continue
+				}
+				fmt.Printf("• %s: %s\n", filename, drift)
+			}
+			return nil
+		},
+	}
+}
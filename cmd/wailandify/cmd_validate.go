@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jassielof/wailandify/internal/schema"
+	"github.com/spf13/cobra"
+)
+
+func newValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate [path]",
+		Short: "Check config.json and its drop-ins for unknown or misspelled fields",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configPath := opts.ConfigPath
+			if len(args) == 1 {
+				configPath = args[0]
+			}
+			if configPath == "" {
+				path, err := defaultConfigPath()
+				if err != nil {
+					return err
+				}
+				configPath = path
+			}
+			configDir := filepath.Dir(configPath)
+
+			total := 0
+			issues, err := validateFile(configPath, schema.ValidateConfig)
+			if err != nil {
+				if os.IsNotExist(err) {
+					fmt.Printf("⚠️  %s does not exist\n", configPath)
+				} else {
+					return err
+				}
+			} else {
+				total += reportIssues(configPath, issues)
+			}
+
+			for _, dropin := range []struct {
+				glob     string
+				validate func([]byte) ([]schema.Issue, error)
+			}{
+				{filepath.Join(configDir, "config.d", "*.json"), schema.ValidateConfig},
+				{filepath.Join(configDir, "flagsets.d", "*.json"), schema.ValidateFlagSetsFragment},
+				{filepath.Join(configDir, "browsers.d", "*.json"), schema.ValidateBrowsersFragment},
+			} {
+				matches, err := filepath.Glob(dropin.glob)
+				if err != nil {
+					return fmt.Errorf("could not glob %s: %w", dropin.glob, err)
+				}
+				for _, path := range matches {
+					issues, err := validateFile(path, dropin.validate)
+					if err != nil {
+						return err
+					}
+					total += reportIssues(path, issues)
+				}
+			}
+
+			if total > 0 {
+				return fmt.Errorf("%d issue(s) found", total)
+			}
+			fmt.Println("✅ Config looks good")
+			return nil
+		},
+	}
+}
+
+// validateFile reads path and validates it against validate.
+func validateFile(path string, validate func([]byte) ([]schema.Issue, error)) ([]schema.Issue, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := validate(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return issues, nil
+}
+
+func reportIssues(path string, issues []schema.Issue) int {
+	for _, issue := range issues {
+		fmt.Printf("❌ %s:%s\n", path, issue)
+	}
+	return len(issues)
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/jassielof/wailandify/internal/hostenv"
+	"github.com/jassielof/wailandify/internal/state"
+	"github.com/spf13/cobra"
+)
+
+// debounceWindow coalesces the burst of CREATE/MODIFY events a package
+// manager or Flatpak install produces into a single re-sync per file.
+const debounceWindow = 500 * time.Millisecond
+
+func newWatchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "watch",
+		Short: "Stay resident and re-sync automatically on browser updates",
+		Long: "Watches the system and user applications directories for .desktop file\n" +
+			"changes (browser updates, Flatpak installs) and re-runs apply for just the\n" +
+			"file that changed, instead of requiring a manual re-run after every update.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := loadConfigOrExit()
+			journal, err := openJournalOrExit()
+			if err != nil {
+				return err
+			}
+			return runWatch(config, journal)
+		},
+	}
+}
+
+// watchedDirs returns every directory that can gain or change a tracked
+// .desktop file: the system and user directories apply already reads from,
+// plus the Flatpak export directories PWAs and Flatpak browsers install into.
+func watchedDirs() []string {
+	dirs := []string{opts.SystemDir, opts.UserDir, "/var/lib/flatpak/exports/share/applications"}
+	if home := os.Getenv("HOME"); home != "" {
+		dirs = append(dirs, filepath.Join(home, ".local/share/flatpak/exports/share/applications"))
+	}
+	return dirs
+}
+
+func runWatch(config AppConfig, journal *state.Journal) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("could not start file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	watching := 0
+	for _, dir := range watchedDirs() {
+		if err := watcher.Add(dir); err != nil {
+			if !opts.Quiet {
+				fmt.Printf("⚠️  Not watching %s: %v\n", dir, err)
+			}
+			continue
+		}
+		watching++
+	}
+	if watching == 0 {
+		return fmt.Errorf("none of the watched directories could be opened")
+	}
+	if !opts.Quiet {
+		fmt.Printf("👀 Watching %d directories for .desktop changes (Ctrl+C to stop)\n", watching)
+	}
+
+	var mu sync.Mutex
+	pending := make(map[string]bool)
+	var timer *time.Timer
+
+	flush := func() {
+		mu.Lock()
+		filenames := make([]string, 0, len(pending))
+		for filename := range pending {
+			filenames = append(filenames, filename)
+		}
+		pending = make(map[string]bool)
+		mu.Unlock()
+
+		for _, filename := range filenames {
+			resyncFile(config, journal, filename)
+		}
+	}
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if !strings.HasSuffix(event.Name, ".desktop") {
+				continue
+			}
+			if event.Op&(fsnotify.Create|fsnotify.Write) == 0 {
+				continue
+			}
+
+			mu.Lock()
+			pending[filepath.Base(event.Name)] = true
+			mu.Unlock()
+
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(debounceWindow, flush)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Printf("⚠️  Watch error: %v\n", err)
+		}
+	}
+}
+
+// resyncFile re-runs apply for a single filename against whichever browser
+// owns it. It skips the event entirely if the journal already shows the
+// user directory's content matching our last write — otherwise every write
+// we make would immediately re-trigger this same handler.
+func resyncFile(config AppConfig, journal *state.Journal, filename string) {
+	info := hostenv.Detect()
+	session := hostenv.SessionEnv()
+
+	for _, browser := range config.Browsers {
+		if !opts.includesBrowser(browser.Name) {
+			continue
+		}
+		if !desktopFileBelongsToBrowser(browser, filename) {
+			continue
+		}
+
+		combinedFlags := computeBrowserFlags(browser, config, info, session)
+		if len(combinedFlags) == 0 {
+			return
+		}
+
+		if current, err := os.ReadFile(filepath.Join(opts.UserDir, filename)); err == nil {
+			if drift, _, err := journal.Classify(filename, current); err == nil && drift == state.DriftUpToDate {
+				return
+			}
+		}
+
+		result, err := processDesktopFile(opts, filename, combinedFlags)
+		if err != nil {
+			fmt.Printf("⚠️  Warning: could not process %s: %v\n", filename, err)
+			return
+		}
+		if result.Action == ActionWillModify {
+			if _, err := journal.RecordApply(filename, result.OriginalContent, result.NewContent, browser.FlagSets); err != nil {
+				fmt.Printf("⚠️  Warning: could not record state for %s: %v\n", filename, err)
+				return
+			}
+		}
+		if err := result.Write(); err != nil {
+			fmt.Printf("⚠️  Warning: could not write %s: %v\n", filename, err)
+			return
+		}
+		if result.Action == ActionWillModify && !opts.Quiet {
+			fmt.Printf("🔄 Re-synced %s (%s)\n", filename, browser.Name)
+		}
+		return
+	}
+}
+
+// desktopFileBelongsToBrowser reports whether filename is one of browser's
+// explicit DesktopFiles or matches its PWA patterns (and isn't excluded).
+func desktopFileBelongsToBrowser(browser BrowserConfig, filename string) bool {
+	for _, name := range browser.DesktopFiles {
+		if name == filename {
+			return true
+		}
+	}
+
+	matched := false
+	for _, pattern := range browser.PWAPatterns {
+		if ok, _ := filepath.Match(pattern, filename); ok {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return false
+	}
+	for _, pattern := range browser.ExcludePatterns {
+		if ok, _ := filepath.Match(pattern, filename); ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jassielof/wailandify/internal/hostenv"
+)
+
+// AppConfig represents the top-level structure of the config file.
+type AppConfig struct {
+	FlagSets map[string]FlagSet `json:"flagSets"`
+	Browsers []BrowserConfig    `json:"browsers"`
+}
+
+type FlagSet struct {
+	Name  string   `json:"name"`
+	Flags []string `json:"flags"`
+	// AppliesWhen restricts this flag set to hosts matching the predicate
+	// (e.g. only on GNOME, or only with an Intel/AMD GPU). Nil means always.
+	AppliesWhen *hostenv.Predicate `json:"appliesWhen,omitempty"`
+	// When restricts this flag set to one session class (see
+	// hostenv.SessionEnv): "wayland-gnome", "wayland-kde", "wayland-other",
+	// or "x11". Empty means always. Define two flag sets with different
+	// When values under the same browser to get a Wayland/X11 fallback pair.
+	When hostenv.SessionClass `json:"when,omitempty"`
+}
+
+// PortalPolicy controls whether a browser's Wayland flags are allowed to
+// disable xdg-desktop-portal integration (GlobalShortcutsPortal and
+// friends). "auto" keeps this tool's historical default of disabling it;
+// "prefer" strips any such disabling so the portal path is used; "disable"
+// forces it off even if a flag set doesn't otherwise request that.
+type PortalPolicy string
+
+const (
+	PortalAuto    PortalPolicy = "auto"
+	PortalPrefer  PortalPolicy = "prefer"
+	PortalDisable PortalPolicy = "disable"
+)
+
+type BrowserConfig struct {
+	Name            string       `json:"name"`
+	Description     string       `json:"description"`
+	DesktopFiles    []string     `json:"desktopFiles"`
+	PWAPatterns     []string     `json:"pwaPatterns"`
+	ExcludePatterns []string     `json:"excludePatterns"`
+	FlagSets        []string     `json:"flagSets"`
+	PortalPolicy    PortalPolicy `json:"portalPolicy,omitempty"`
+	// FlagFiles are shell-style Chromium flag files (e.g. ~/.config/brave-flags.conf)
+	// whose contents are appended after FlagSets, letting users tweak flags
+	// without touching config.json. ~ and $VARS in each path are expanded.
+	FlagFiles []string `json:"flagFiles,omitempty"`
+	// PipewireCapture opts this browser into auto-appending
+	// --enable-features=WebRTCPipeWireCapturer whenever it's launched on a
+	// Wayland session, instead of requiring a dedicated flag set per desktop.
+	PipewireCapture bool `json:"pipewireCapture,omitempty"`
+}
+
+// getDefaultConfig returns the hardcoded default configuration.
+func getDefaultConfig() AppConfig {
+	return AppConfig{
+		FlagSets: map[string]FlagSet{
+			"wayland_basic": {
+				Name:  "Basic Wayland Support",
+				Flags: []string{"--ozone-platform=wayland"},
+			},
+			"wayland_full": {
+				Name: "Full Wayland Support",
+				Flags: []string{
+					"--ozone-platform=wayland",
+					"--enable-features=UseOzonePlatform,WaylandWindowDecorations",
+					"--ozone-platform-hint=auto",
+				},
+				// Whether this also disables GlobalShortcutsPortal is decided
+				// per-browser by BrowserConfig.PortalPolicy, not baked in here.
+			},
+			"touchpad_gestures": {
+				Name:  "Touchpad Gestures",
+				Flags: []string{"--enable-features=TouchpadOverscrollHistoryNavigation"},
+			},
+			"edge_wayland": {
+				Name:  "Edge Wayland Fix",
+				Flags: []string{"--ozone-platform=wayland"},
+			},
+			"gnome_gtk4": {
+				Name:        "GTK4 Titlebar (GNOME only)",
+				Flags:       []string{"--gtk-version=4"},
+				AppliesWhen: &hostenv.Predicate{CurrentDesktop: "gnome"},
+			},
+			"vaapi_intel": {
+				Name:        "VA-API Video Decode (Intel GPU)",
+				Flags:       []string{"--enable-features=VaapiVideoDecoder"},
+				AppliesWhen: &hostenv.Predicate{GPUVendor: "intel"},
+			},
+			"vaapi_amd": {
+				Name:        "VA-API Video Decode (AMD GPU)",
+				Flags:       []string{"--enable-features=VaapiVideoDecoder"},
+				AppliesWhen: &hostenv.Predicate{GPUVendor: "amd"},
+			},
+		},
+		Browsers: []BrowserConfig{
+			{
+				Name:            "Brave Browser",
+				DesktopFiles:    []string{"brave-browser.desktop", "brave-browser-dev.desktop", "brave-browser-beta.desktop"},
+				PWAPatterns:     []string{"brave-*.desktop"},
+				ExcludePatterns: []string{"brave-browser*.desktop"},
+				FlagSets:        []string{"touchpad_gestures", "wayland_full", "gnome_gtk4", "vaapi_intel", "vaapi_amd"},
+				PortalPolicy:    PortalDisable,
+				Description:     "Brave Browser (all variants)",
+			},
+			{
+				Name:            "Microsoft Edge",
+				DesktopFiles:    []string{"microsoft-edge.desktop", "microsoft-edge-dev.desktop", "microsoft-edge-beta.desktop"},
+				PWAPatterns:     []string{"msedge-*.desktop"},
+				ExcludePatterns: []string{"microsoft-edge*.desktop"},
+				FlagSets:        []string{"touchpad_gestures", "edge_wayland"},
+				Description:     "Microsoft Edge (all variants)",
+			},
+			{
+				Name:         "Visual Studio Code",
+				DesktopFiles: []string{"code.desktop", "code-insiders.desktop"},
+				FlagSets:     []string{"wayland_basic"},
+				Description:  "Visual Studio Code",
+			},
+			{
+				Name:            "Opera",
+				DesktopFiles:    []string{"opera.desktop", "opera-developer.desktop"},
+				PWAPatterns:     []string{"opera-*.desktop"},
+				ExcludePatterns: []string{"opera.desktop", "opera-developer.desktop"},
+				FlagSets:        []string{"touchpad_gestures", "wayland_basic"},
+				Description:     "Opera Browser",
+			},
+			{
+				Name:            "Vivaldi",
+				DesktopFiles:    []string{"vivaldi-stable.desktop", "vivaldi-beta.desktop"},
+				PWAPatterns:     []string{"vivaldi-*.desktop"},
+				ExcludePatterns: []string{"vivaldi-*.desktop"},
+				FlagSets:        []string{"touchpad_gestures", "wayland_basic"},
+				Description:     "Vivaldi Browser",
+			},
+		},
+	}
+}
+
+// userConfigHome returns $XDG_CONFIG_HOME if set, falling back to
+// os.UserConfigDir() (which itself honors $XDG_CONFIG_HOME on Linux, but
+// only when it's an absolute path — this also accepts a relative one).
+func userConfigHome() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return dir, nil
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("could not get user config directory: %w", err)
+	}
+	return configDir, nil
+}
+
+// defaultConfigPath returns ~/.config/wailandify/config.json (or wherever
+// $XDG_CONFIG_HOME points).
+func defaultConfigPath() (string, error) {
+	configDir, err := userConfigHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "wailandify", "config.json"), nil
+}
+
+// loadConfiguration builds the effective AppConfig by layering, lowest
+// priority first: any packager-shipped /etc/wailandify defaults, the config file
+// at configPath (creating a default one if it doesn't exist), and finally
+// the user's own config.d/flagsets.d/browsers.d drop-ins alongside it. An
+// empty configPath resolves to defaultConfigPath(), which is what every
+// subcommand does unless --config was passed.
+func loadConfiguration(configPath string) (AppConfig, error) {
+	var config AppConfig
+
+	config, err := mergeSystemDefaults(config)
+	if err != nil {
+		return config, err
+	}
+
+	if configPath == "" {
+		path, err := defaultConfigPath()
+		if err != nil {
+			return config, err
+		}
+		configPath = path
+	}
+	appConfigDir := filepath.Dir(configPath)
+
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		fmt.Printf("💡 No config file found. Creating a default one at: %s\n", configPath)
+		defaultConfig := getDefaultConfig()
+
+		if err := os.MkdirAll(appConfigDir, 0755); err != nil {
+			return config, fmt.Errorf("could not create config directory: %w", err)
+		}
+
+		file, err := os.Create(configPath)
+		if err != nil {
+			return config, fmt.Errorf("could not create config file: %w", err)
+		}
+		defer file.Close()
+
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(defaultConfig); err != nil {
+			return config, fmt.Errorf("could not write default config: %w", err)
+		}
+
+		config = mergeAppConfig(config, defaultConfig)
+		return mergeConfigDropins(config, appConfigDir)
+	}
+
+	file, err := os.ReadFile(configPath)
+	if err != nil {
+		return config, fmt.Errorf("could not read config file: %w", err)
+	}
+
+	var userConfig AppConfig
+	if err := json.Unmarshal(file, &userConfig); err != nil {
+		return config, fmt.Errorf("could not parse config file: %w", err)
+	}
+	config = mergeAppConfig(config, userConfig)
+
+	return mergeConfigDropins(config, appConfigDir)
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// systemConfigDir is where packagers can ship distro-wide defaults. It's
+// merged in first, so both the user's config.json and their drop-ins take
+// priority over it.
+const systemConfigDir = "/etc/wailandify"
+
+// mergeSystemDefaults layers /etc/wailandify/config.json and its drop-ins under
+// config. Missing files are not an error — most installs won't have any.
+func mergeSystemDefaults(config AppConfig) (AppConfig, error) {
+	systemConfigPath := filepath.Join(systemConfigDir, "config.json")
+	data, err := os.ReadFile(systemConfigPath)
+	switch {
+	case os.IsNotExist(err):
+		// no system-wide defaults shipped
+	case err != nil:
+		return config, fmt.Errorf("could not read %s: %w", systemConfigPath, err)
+	default:
+		var systemConfig AppConfig
+		if err := json.Unmarshal(data, &systemConfig); err != nil {
+			return config, fmt.Errorf("could not parse %s: %w", systemConfigPath, err)
+		}
+		config = mergeAppConfig(config, systemConfig)
+	}
+
+	return mergeConfigDropins(config, systemConfigDir)
+}
+
+// mergeConfigDropins merges, in lexicographic filename order, every
+// config.d/*.json (full AppConfig fragments), flagsets.d/*.json (flag set
+// maps), and browsers.d/*.json (browser lists) found under dir into config.
+// Later files in the same directory override earlier ones.
+func mergeConfigDropins(config AppConfig, dir string) (AppConfig, error) {
+	fragments, err := globSorted(filepath.Join(dir, "config.d", "*.json"))
+	if err != nil {
+		return config, err
+	}
+	for _, path := range fragments {
+		var fragment AppConfig
+		if err := readJSONFile(path, &fragment); err != nil {
+			return config, err
+		}
+		config = mergeAppConfig(config, fragment)
+	}
+
+	flagSetFiles, err := globSorted(filepath.Join(dir, "flagsets.d", "*.json"))
+	if err != nil {
+		return config, err
+	}
+	for _, path := range flagSetFiles {
+		var flagSets map[string]FlagSet
+		if err := readJSONFile(path, &flagSets); err != nil {
+			return config, err
+		}
+		config.FlagSets = mergeFlagSets(config.FlagSets, flagSets)
+	}
+
+	browserFiles, err := globSorted(filepath.Join(dir, "browsers.d", "*.json"))
+	if err != nil {
+		return config, err
+	}
+	for _, path := range browserFiles {
+		var browsers []BrowserConfig
+		if err := readJSONFile(path, &browsers); err != nil {
+			return config, err
+		}
+		config.Browsers = mergeBrowsers(config.Browsers, browsers)
+	}
+
+	return config, nil
+}
+
+func globSorted(pattern string) ([]string, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("could not glob %s: %w", pattern, err)
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
+func readJSONFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("could not read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("could not parse %s: %w", path, err)
+	}
+	return nil
+}
+
+// mergeAppConfig layers src's flag sets and browsers on top of dst.
+func mergeAppConfig(dst, src AppConfig) AppConfig {
+	dst.FlagSets = mergeFlagSets(dst.FlagSets, src.FlagSets)
+	dst.Browsers = mergeBrowsers(dst.Browsers, src.Browsers)
+	return dst
+}
+
+// mergeFlagSets overrides dst's entries with src's by key, whole-value — a
+// flag set with the same key as one seen in an earlier file replaces it
+// entirely rather than merging field-by-field.
+func mergeFlagSets(dst, src map[string]FlagSet) map[string]FlagSet {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[string]FlagSet, len(src))
+	}
+	for key, flagSet := range src {
+		dst[key] = flagSet
+	}
+	return dst
+}
+
+// mergeBrowsers matches src entries against dst by Name: a match is merged
+// field-by-field via mergeBrowserConfig, and anything new is appended.
+func mergeBrowsers(dst, src []BrowserConfig) []BrowserConfig {
+	for _, browser := range src {
+		if i := browserIndexByName(dst, browser.Name); i != -1 {
+			dst[i] = mergeBrowserConfig(dst[i], browser)
+		} else {
+			dst = append(dst, browser)
+		}
+	}
+	return dst
+}
+
+func browserIndexByName(browsers []BrowserConfig, name string) int {
+	for i, browser := range browsers {
+		if browser.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// mergeBrowserConfig applies override on top of base: scalar fields replace
+// when set, and the list fields are unioned (deduped, base order first) so a
+// drop-in can add a pattern or flag set without repeating the whole list.
+func mergeBrowserConfig(base, override BrowserConfig) BrowserConfig {
+	if override.Description != "" {
+		base.Description = override.Description
+	}
+	if override.PortalPolicy != "" {
+		base.PortalPolicy = override.PortalPolicy
+	}
+	if override.PipewireCapture {
+		base.PipewireCapture = true
+	}
+	base.DesktopFiles = unionStrings(base.DesktopFiles, override.DesktopFiles)
+	base.PWAPatterns = unionStrings(base.PWAPatterns, override.PWAPatterns)
+	base.ExcludePatterns = unionStrings(base.ExcludePatterns, override.ExcludePatterns)
+	base.FlagSets = unionStrings(base.FlagSets, override.FlagSets)
+	base.FlagFiles = unionStrings(base.FlagFiles, override.FlagFiles)
+	return base
+}
+
+func unionStrings(base, extra []string) []string {
+	seen := make(map[string]bool, len(base)+len(extra))
+	out := make([]string, 0, len(base)+len(extra))
+	for _, values := range [][]string{base, extra} {
+		for _, value := range values {
+			if !seen[value] {
+				seen[value] = true
+				out = append(out, value)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeFlagSetsOverridesByKeyWhole(t *testing.T) {
+	dst := map[string]FlagSet{
+		"wayland_basic": {Name: "Basic", Flags: []string{"--ozone-platform=wayland"}},
+	}
+	src := map[string]FlagSet{
+		"wayland_basic": {Name: "Overridden", Flags: []string{"--foo"}},
+		"new_set":       {Name: "New", Flags: []string{"--bar"}},
+	}
+
+	got := mergeFlagSets(dst, src)
+
+	if got["wayland_basic"].Name != "Overridden" || len(got["wayland_basic"].Flags) != 1 || got["wayland_basic"].Flags[0] != "--foo" {
+		t.Errorf("expected src to fully replace dst's entry, got %#v", got["wayland_basic"])
+	}
+	if got["new_set"].Name != "New" {
+		t.Errorf("expected new_set to be added, got %#v", got)
+	}
+}
+
+func TestMergeFlagSetsEmptySrcLeavesDstUntouched(t *testing.T) {
+	dst := map[string]FlagSet{"a": {Name: "A"}}
+	got := mergeFlagSets(dst, nil)
+	if !reflect.DeepEqual(got, dst) {
+		t.Errorf("mergeFlagSets(dst, nil) = %#v, want %#v", got, dst)
+	}
+}
+
+func TestMergeBrowserConfigScalarOverride(t *testing.T) {
+	base := BrowserConfig{Name: "Brave", Description: "old", PortalPolicy: PortalDisable}
+	override := BrowserConfig{Name: "Brave", Description: "new"}
+
+	got := mergeBrowserConfig(base, override)
+
+	if got.Description != "new" {
+		t.Errorf("expected Description to be overridden, got %q", got.Description)
+	}
+	if got.PortalPolicy != PortalDisable {
+		t.Errorf("expected PortalPolicy to be left alone when override doesn't set it, got %q", got.PortalPolicy)
+	}
+}
+
+func TestMergeBrowserConfigUnionsListFields(t *testing.T) {
+	base := BrowserConfig{FlagSets: []string{"a", "b"}}
+	override := BrowserConfig{FlagSets: []string{"b", "c"}}
+
+	got := mergeBrowserConfig(base, override)
+
+	want := []string{"a", "b", "c"}
+	if !reflect.DeepEqual(got.FlagSets, want) {
+		t.Errorf("mergeBrowserConfig FlagSets = %#v, want %#v", got.FlagSets, want)
+	}
+}
+
+func TestMergeBrowsersMatchesByNameAndAppendsNew(t *testing.T) {
+	dst := []BrowserConfig{{Name: "Brave", Description: "old"}}
+	src := []BrowserConfig{
+		{Name: "Brave", Description: "new"},
+		{Name: "Edge", Description: "brand new"},
+	}
+
+	got := mergeBrowsers(dst, src)
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 browsers, got %d: %#v", len(got), got)
+	}
+	if got[0].Name != "Brave" || got[0].Description != "new" {
+		t.Errorf("expected Brave to be merged in place, got %#v", got[0])
+	}
+	if got[1].Name != "Edge" {
+		t.Errorf("expected Edge to be appended, got %#v", got[1])
+	}
+}
+
+func TestUnionStringsDedupesPreservingBaseOrder(t *testing.T) {
+	got := unionStrings([]string{"x", "y"}, []string{"y", "z"})
+	want := []string{"x", "y", "z"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unionStrings = %#v, want %#v", got, want)
+	}
+}
+
+func TestMergeAppConfigLaterDropinWins(t *testing.T) {
+	dst := AppConfig{
+		FlagSets: map[string]FlagSet{"a": {Name: "A1"}},
+		Browsers: []BrowserConfig{{Name: "Brave", Description: "d1"}},
+	}
+	middle := AppConfig{
+		FlagSets: map[string]FlagSet{"a": {Name: "A2"}},
+		Browsers: []BrowserConfig{{Name: "Brave", Description: "d2"}},
+	}
+	last := AppConfig{
+		FlagSets: map[string]FlagSet{"a": {Name: "A3"}},
+	}
+
+	merged := mergeAppConfig(dst, middle)
+	merged = mergeAppConfig(merged, last)
+
+	if merged.FlagSets["a"].Name != "A3" {
+		t.Errorf("expected the last-merged fragment to win, got %q", merged.FlagSets["a"].Name)
+	}
+	if merged.Browsers[0].Description != "d2" {
+		t.Errorf("expected the last fragment that touched Browsers to win, got %q", merged.Browsers[0].Description)
+	}
+}
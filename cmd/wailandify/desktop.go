@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jassielof/wailandify/internal/desktop"
+)
+
+// FileAction describes what processDesktopFile decided to do with a single
+// .desktop file, without necessarily having done it yet — apply and dry-run
+// share this plan and only differ in whether they write it to disk.
+type FileAction int
+
+const (
+	ActionSkippedNoSource FileAction = iota
+	ActionUpToDate
+	ActionWillCopyOnly
+	ActionWillModify
+)
+
+// FileResult is what processDesktopFile returns for a single entry. Diff is
+// empty unless the content changed; Write() performs the actual copy/write.
+type FileResult struct {
+	Filename        string
+	SrcPath         string
+	DstPath         string
+	Action          FileAction
+	NeedsCopyFirst  bool
+	CopySourceBytes []byte
+	OriginalContent []byte
+	NewContent      []byte
+	ModifiedCount   int
+	Diff            string
+}
+
+// Write performs the filesystem side effects a FileResult describes: copying
+// the system file into the user directory if needed, then writing the
+// modified content. Called by the apply subcommand; dry-run/diff never call it.
+func (r FileResult) Write() error {
+	if r.NeedsCopyFirst {
+		if err := os.WriteFile(r.DstPath, r.CopySourceBytes, 0644); err != nil {
+			return fmt.Errorf("could not copy %s to user directory: %w", r.Filename, err)
+		}
+	}
+	if r.Action != ActionWillModify {
+		return nil
+	}
+	if err := os.WriteFile(r.DstPath, r.NewContent, 0644); err != nil {
+		return fmt.Errorf("could not write updated file %s: %w", r.Filename, err)
+	}
+	return nil
+}
+
+// findPWADesktopFiles scans only the user's application directory for PWA desktop files.
+func findPWADesktopFiles(userDir string, patterns, excludePatterns []string) ([]string, error) {
+	var pwaFiles []string
+
+	entries, err := os.ReadDir(userDir)
+	if err != nil {
+		// If the directory doesn't exist, it's not an error, just no files found.
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not read user applications directory: %v", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		name := entry.Name()
+
+		// Check if file matches any pattern
+		matched := false
+		for _, pattern := range patterns {
+			if matched, _ = filepath.Match(pattern, name); matched {
+				break
+			}
+		}
+
+		if !matched {
+			continue
+		}
+
+		// Check if file should be excluded
+		excluded := false
+		for _, excludePattern := range excludePatterns {
+			if matched, _ = filepath.Match(excludePattern, name); matched {
+				excluded = true
+				break
+			}
+		}
+
+		if excluded {
+			continue
+		}
+
+		pwaFiles = append(pwaFiles, name)
+	}
+
+	return pwaFiles, nil
+}
+
+// processDesktopFile works out what would happen to a single .desktop file
+// for the given flags, without touching disk. The caller (apply, dry-run or
+// diff) decides whether to call FileResult.Write().
+func processDesktopFile(opts Options, filename string, flags []string) (FileResult, error) {
+	srcPath := filepath.Join(opts.SystemDir, filename)
+	dstPath := filepath.Join(opts.UserDir, filename)
+
+	result := FileResult{
+		Filename: filename,
+		SrcPath:  srcPath,
+		DstPath:  dstPath,
+	}
+
+	content, err := os.ReadFile(dstPath)
+	switch {
+	case os.IsNotExist(err):
+		srcContent, srcErr := os.ReadFile(srcPath)
+		if os.IsNotExist(srcErr) {
+			// Neither copy exists (e.g. a beta/dev browser that isn't installed).
+			// Not an error worth surfacing.
+			result.Action = ActionSkippedNoSource
+			return result, nil
+		}
+		if srcErr != nil {
+			return result, fmt.Errorf("could not read system file %s: %w", filename, srcErr)
+		}
+		result.NeedsCopyFirst = true
+		result.CopySourceBytes = srcContent
+		content = srcContent
+	case err != nil:
+		return result, fmt.Errorf("could not read user file %s: %w", filename, err)
+	}
+
+	result.OriginalContent = content
+
+	modifiedContent, modifiedCount, err := modifyDesktopContent(content, flags)
+	if err != nil {
+		return result, fmt.Errorf("could not process %s: %w", filename, err)
+	}
+	result.NewContent = modifiedContent
+	result.ModifiedCount = modifiedCount
+
+	if bytes.Equal(content, modifiedContent) {
+		if result.NeedsCopyFirst {
+			result.Action = ActionWillCopyOnly
+		} else {
+			result.Action = ActionUpToDate
+		}
+		return result, nil
+	}
+
+	result.Action = ActionWillModify
+	result.Diff = unifiedDiff(filename, content, modifiedContent)
+	return result, nil
+}
+
+// modifyDesktopContent parses content as a full Desktop Entry file and
+// applies flags to every Exec= line — including ones inside
+// [Desktop Action ...] groups — rather than just the line matching a single
+// top-level regex. Everything else (comments, blank lines, key order,
+// locale-suffixed keys) round-trips unchanged.
+func modifyDesktopContent(content []byte, flags []string) ([]byte, int, error) {
+	entry, err := desktop.Parse(content)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	modifiedCount := entry.EachExec(func(group, exec string) string {
+		return desktop.ApplyFlags(exec, flags)
+	})
+
+	return entry.Bytes(), modifiedCount, nil
+}
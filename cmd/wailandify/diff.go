@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// unifiedDiff renders a minimal unified diff between before and after for
+// dry-run/diff output. It's line-based (good enough for small .desktop
+// files) rather than a full Myers/LCS implementation.
+func unifiedDiff(filename string, before, after []byte) string {
+	if bytes.Equal(before, after) {
+		return ""
+	}
+
+	oldLines := splitLines(before)
+	newLines := splitLines(after)
+	lcs := longestCommonSubsequence(oldLines, newLines)
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "--- a/%s\n", filename)
+	fmt.Fprintf(&buf, "+++ b/%s\n", filename)
+
+	i, j, k := 0, 0, 0
+	for i < len(oldLines) || j < len(newLines) {
+		switch {
+		case k < len(lcs) && i < len(oldLines) && j < len(newLines) && oldLines[i] == lcs[k] && newLines[j] == lcs[k]:
+			buf.WriteString(" " + oldLines[i] + "\n")
+			i++
+			j++
+			k++
+		case i < len(oldLines) && (k >= len(lcs) || oldLines[i] != lcs[k]):
+			buf.WriteString("-" + oldLines[i] + "\n")
+			i++
+		case j < len(newLines) && (k >= len(lcs) || newLines[j] != lcs[k]):
+			buf.WriteString("+" + newLines[j] + "\n")
+			j++
+		default:
+			// Keep the loop terminating even if the cases above somehow fail to match.
+			i++
+			j++
+		}
+	}
+
+	return buf.String()
+}
+
+func splitLines(content []byte) []string {
+	text := strings.TrimSuffix(string(content), "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// longestCommonSubsequence returns the LCS of a and b, used to keep the
+// unchanged-line runs in unifiedDiff stable instead of diffing everything.
+func longestCommonSubsequence(a, b []string) []string {
+	n, m := len(a), len(b)
+	table := make([][]int, n+1)
+	for i := range table {
+		table[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i][j] = table[i+1][j+1] + 1
+			} else if table[i+1][j] >= table[i][j+1] {
+				table[i][j] = table[i+1][j]
+			} else {
+				table[i][j] = table[i][j+1]
+			}
+		}
+	}
+
+	var lcs []string
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			lcs = append(lcs, a[i])
+			i++
+			j++
+		case table[i+1][j] >= table[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return lcs
+}
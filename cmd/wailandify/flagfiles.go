@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// loadFlagFiles reads and concatenates every path in order, in the format
+// used by Chromium-family *-flags.conf files (blank lines and '#' comments
+// ignored, '\' line continuation, simple shell quoting). A path that
+// doesn't resolve to an existing file is skipped rather than treated as an
+// error, since FlagFiles are an optional, user-maintained customization.
+func loadFlagFiles(paths []string) ([]string, error) {
+	var flags []string
+	for _, path := range paths {
+		fileFlags, err := parseFlagFile(path)
+		if err != nil {
+			return flags, fmt.Errorf("could not read flag file %q: %w", path, err)
+		}
+		flags = append(flags, fileFlags...)
+	}
+	return flags, nil
+}
+
+func parseFlagFile(path string) ([]string, error) {
+	resolved, err := resolveFlagFilePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(resolved)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var flags []string
+	var pending strings.Builder
+	for _, raw := range strings.Split(string(data), "\n") {
+		line := strings.TrimRight(raw, "\r")
+		if pending.Len() > 0 {
+			line = pending.String() + line
+			pending.Reset()
+		}
+		if strings.HasSuffix(line, "\\") {
+			pending.WriteString(strings.TrimSuffix(line, "\\"))
+			continue
+		}
+
+		content := strings.TrimSpace(line)
+		if content == "" || strings.HasPrefix(content, "#") {
+			continue
+		}
+		flags = append(flags, shellSplit(content)...)
+	}
+	return flags, nil
+}
+
+// resolveFlagFilePath expands $VARS and a leading ~ in a FlagFiles entry.
+func resolveFlagFilePath(path string) (string, error) {
+	expanded := os.ExpandEnv(path)
+	if expanded == "~" || strings.HasPrefix(expanded, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("could not resolve ~ in %q: %w", path, err)
+		}
+		expanded = filepath.Join(home, strings.TrimPrefix(expanded, "~"))
+	}
+	return expanded, nil
+}
+
+// shellSplit tokenizes a single logical line with enough shell quoting
+// support for flag files: single quotes are literal, double quotes allow
+// \" and \\ escapes, and a bare backslash escapes the next character.
+func shellSplit(line string) []string {
+	var tokens []string
+	var current strings.Builder
+	hasToken := false
+	inSingle, inDouble := false, false
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case inSingle:
+			if c == '\'' {
+				inSingle = false
+			} else {
+				current.WriteByte(c)
+			}
+		case inDouble:
+			if c == '"' {
+				inDouble = false
+			} else if c == '\\' && i+1 < len(line) && (line[i+1] == '"' || line[i+1] == '\\') {
+				i++
+				current.WriteByte(line[i])
+			} else {
+				current.WriteByte(c)
+			}
+		case c == '\'':
+			inSingle, hasToken = true, true
+		case c == '"':
+			inDouble, hasToken = true, true
+		case c == ' ' || c == '\t':
+			if hasToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				hasToken = false
+			}
+		case c == '\\' && i+1 < len(line):
+			i++
+			current.WriteByte(line[i])
+			hasToken = true
+		default:
+			current.WriteByte(c)
+			hasToken = true
+		}
+	}
+	if hasToken {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
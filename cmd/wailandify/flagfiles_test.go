@@ -0,0 +1,94 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFlagFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test-flags.conf")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("could not write flag file: %v", err)
+	}
+	return path
+}
+
+func TestParseFlagFileSkipsCommentsAndBlankLines(t *testing.T) {
+	path := writeFlagFile(t, "# a comment\n\n--ozone-platform=wayland\n\n# another\n--enable-features=UseOzonePlatform\n")
+
+	got, err := parseFlagFile(path)
+	if err != nil {
+		t.Fatalf("parseFlagFile returned error: %v", err)
+	}
+	want := []string{"--ozone-platform=wayland", "--enable-features=UseOzonePlatform"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseFlagFile = %#v, want %#v", got, want)
+	}
+}
+
+func TestParseFlagFileJoinsLineContinuations(t *testing.T) {
+	path := writeFlagFile(t, "--ozone-platform=wayland \\\n--enable-features=UseOzonePlatform\n")
+
+	got, err := parseFlagFile(path)
+	if err != nil {
+		t.Fatalf("parseFlagFile returned error: %v", err)
+	}
+	want := []string{"--ozone-platform=wayland", "--enable-features=UseOzonePlatform"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseFlagFile = %#v, want %#v", got, want)
+	}
+}
+
+func TestParseFlagFileMissingFileReturnsNoError(t *testing.T) {
+	got, err := parseFlagFile(filepath.Join(t.TempDir(), "does-not-exist.conf"))
+	if err != nil {
+		t.Fatalf("parseFlagFile returned error for a missing file: %v", err)
+	}
+	if got != nil {
+		t.Errorf("expected nil flags for a missing file, got %#v", got)
+	}
+}
+
+func TestResolveFlagFilePathExpandsHomeAndEnv(t *testing.T) {
+	t.Setenv("WAILANDIFY_TEST_DIR", "sub")
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Fatalf("could not resolve home dir: %v", err)
+	}
+
+	got, err := resolveFlagFilePath("~/$WAILANDIFY_TEST_DIR/flags.conf")
+	if err != nil {
+		t.Fatalf("resolveFlagFilePath returned error: %v", err)
+	}
+	want := filepath.Join(home, "sub", "flags.conf")
+	if got != want {
+		t.Errorf("resolveFlagFilePath = %q, want %q", got, want)
+	}
+}
+
+func TestShellSplit(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want []string
+	}{
+		{"plain flags", "--ozone-platform=wayland --enable-features=X", []string{"--ozone-platform=wayland", "--enable-features=X"}},
+		{"double quoted value with spaces", `--force-dark-mode-colors="a b c"`, []string{"--force-dark-mode-colors=a b c"}},
+		{"single quoted value is literal", `--title='$HOME'`, []string{"--title=$HOME"}},
+		{"escaped quote inside double quotes", `--msg="say \"hi\""`, []string{`--msg=say "hi"`}},
+		{"backslash escapes a space outside quotes", `--path=foo\ bar`, []string{"--path=foo bar"}},
+		{"extra whitespace between tokens is ignored", "  --a   --b  ", []string{"--a", "--b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := shellSplit(tt.line)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("shellSplit(%q) = %#v, want %#v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
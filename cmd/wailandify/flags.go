@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jassielof/wailandify/internal/hostenv"
+)
+
+// getCombinedFlags merges the named flag sets in order, skipping duplicate
+// flags and any flag set whose AppliesWhen predicate doesn't match info, or
+// whose When session class doesn't match session.
+func getCombinedFlags(flagSetNames []string, flagSets map[string]FlagSet, info hostenv.Info, session hostenv.SessionClass) []string {
+	var combinedFlags []string
+	seen := make(map[string]bool)
+
+	for _, flagSetName := range flagSetNames {
+		flagSet, exists := flagSets[flagSetName]
+		if !exists {
+			continue
+		}
+		if flagSet.AppliesWhen != nil && !flagSet.AppliesWhen.Matches(info) {
+			continue
+		}
+		if flagSet.When != "" && flagSet.When != session {
+			continue
+		}
+		for _, flag := range flagSet.Flags {
+			if !seen[flag] {
+				combinedFlags = append(combinedFlags, flag)
+				seen[flag] = true
+			}
+		}
+	}
+
+	return combinedFlags
+}
+
+// computeBrowserFlags runs the full flag pipeline for one browser: merge its
+// named flag sets, append any external flag files, auto-enable pipewire
+// capture on Wayland, and finally apply the effective portal policy
+// (browser default, overridden by --portal if set). apply/dry-run/diff/watch
+// all share this so the combined flags they act on can't drift apart.
+func computeBrowserFlags(browser BrowserConfig, config AppConfig, info hostenv.Info, session hostenv.SessionClass) []string {
+	combinedFlags := getCombinedFlags(browser.FlagSets, config.FlagSets, info, session)
+	flagFileFlags, err := loadFlagFiles(browser.FlagFiles)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: %v\n", err)
+	}
+	combinedFlags = append(combinedFlags, flagFileFlags...)
+	if browser.PipewireCapture && session.IsWayland() {
+		combinedFlags = ensureEnabledFeature(combinedFlags, pipewireCaptureFeature)
+	}
+	policy := browser.PortalPolicy
+	if opts.Portal != "" {
+		policy = opts.Portal
+	}
+	return applyPortalPolicy(combinedFlags, policy)
+}
+
+const portalFeature = "GlobalShortcutsPortal"
+
+// applyPortalPolicy adjusts --disable-features=/--enable-features= flags so
+// GlobalShortcutsPortal ends up disabled, enabled, or untouched according to
+// policy. An empty policy behaves like PortalAuto.
+func applyPortalPolicy(flags []string, policy PortalPolicy) []string {
+	switch policy {
+	case PortalPrefer:
+		return removeDisabledFeature(flags, portalFeature)
+	case PortalDisable:
+		return ensureDisabledFeature(flags, portalFeature)
+	default: // PortalAuto, or unset
+		return flags
+	}
+}
+
+func removeDisabledFeature(flags []string, feature string) []string {
+	out := make([]string, 0, len(flags))
+	for _, flag := range flags {
+		value, ok := strings.CutPrefix(flag, "--disable-features=")
+		if !ok {
+			out = append(out, flag)
+			continue
+		}
+		remaining := removeCSVEntry(value, feature)
+		if remaining != "" {
+			out = append(out, "--disable-features="+remaining)
+		}
+	}
+	return out
+}
+
+func ensureDisabledFeature(flags []string, feature string) []string {
+	for i, flag := range flags {
+		value, ok := strings.CutPrefix(flag, "--disable-features=")
+		if !ok {
+			continue
+		}
+		if containsCSVEntry(value, feature) {
+			return flags
+		}
+		updated := make([]string, len(flags))
+		copy(updated, flags)
+		updated[i] = "--disable-features=" + value + "," + feature
+		return updated
+	}
+	return append(flags, "--disable-features="+feature)
+}
+
+// ensureEnabledFeature adds feature to an existing --enable-features= flag,
+// or appends a new one if none is present, unless it's already there.
+func ensureEnabledFeature(flags []string, feature string) []string {
+	for i, flag := range flags {
+		value, ok := strings.CutPrefix(flag, "--enable-features=")
+		if !ok {
+			continue
+		}
+		if containsCSVEntry(value, feature) {
+			return flags
+		}
+		updated := make([]string, len(flags))
+		copy(updated, flags)
+		updated[i] = "--enable-features=" + value + "," + feature
+		return updated
+	}
+	return append(flags, "--enable-features="+feature)
+}
+
+const pipewireCaptureFeature = "WebRTCPipeWireCapturer"
+
+func containsCSVEntry(csv, entry string) bool {
+	for _, item := range strings.Split(csv, ",") {
+		if item == entry {
+			return true
+		}
+	}
+	return false
+}
+
+func removeCSVEntry(csv, entry string) string {
+	items := strings.Split(csv, ",")
+	out := items[:0:0]
+	for _, item := range items {
+		if item != entry {
+			out = append(out, item)
+		}
+	}
+	return strings.Join(out, ",")
+}
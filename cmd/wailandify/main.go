@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jassielof/wailandify/internal/hostenv"
+	"github.com/jassielof/wailandify/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var opts Options
+var portalFlag string
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "wailandify",
+		Short: "Apply Wayland/portal flags to installed browser .desktop entries",
+	}
+
+	root.PersistentFlags().StringVar(&opts.ConfigPath, "config", "", "path to config.json (default: ~/.config/wailandify/config.json)")
+	root.PersistentFlags().StringVar(&opts.SystemDir, "system-dir", "/usr/share/applications", "directory with the original system .desktop files")
+	root.PersistentFlags().StringVar(&opts.UserDir, "user-dir", filepath.Join(os.Getenv("HOME"), ".local/share/applications"), "directory with the user's .desktop overrides")
+	root.PersistentFlags().StringVar(&opts.StateDir, "state-dir", "", "directory for backups and the state journal (default: ~/.local/state/wailandify)")
+	root.PersistentFlags().BoolVarP(&opts.Verbose, "verbose", "v", false, "print per-file details")
+	root.PersistentFlags().BoolVarP(&opts.Quiet, "quiet", "q", false, "only print warnings and errors")
+	root.PersistentFlags().StringVar(&opts.Only, "only", "", "comma-separated list of browser names to process (default: all)")
+	root.PersistentFlags().StringVar(&portalFlag, "portal", "", "override every browser's portal policy: auto, prefer, or disable")
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		switch PortalPolicy(portalFlag) {
+		case "", PortalAuto, PortalPrefer, PortalDisable:
+			opts.Portal = PortalPolicy(portalFlag)
+			return nil
+		default:
+			return fmt.Errorf("invalid --portal value %q (want auto, prefer, or disable)", portalFlag)
+		}
+	}
+
+	root.AddCommand(
+		newApplyCmd(),
+		newDryRunCmd(),
+		newRevertCmd(),
+		newDiffCmd(),
+		newListFlagsetsCmd(),
+		newListBrowsersCmd(),
+		newStatusCmd(),
+		newWatchCmd(),
+		newSchemaCmd(),
+		newValidateCmd(),
+		newConfigCmd(),
+	)
+	return root
+}
+
+// openJournalOrExit resolves --state-dir (or its default) and opens the
+// state journal, creating its directories on first use.
+func openJournalOrExit() (*state.Journal, error) {
+	baseDir := opts.StateDir
+	if baseDir == "" {
+		dir, err := state.DefaultBaseDir()
+		if err != nil {
+			return nil, err
+		}
+		baseDir = dir
+	}
+	return state.Open(baseDir)
+}
+
+// forEachCandidate walks every browser's desktop files (main + discovered
+// PWAs) matching --only, running fn on each before any of them are written.
+func forEachCandidate(config AppConfig, fn func(browser BrowserConfig, filename string, result FileResult)) error {
+	if err := os.MkdirAll(opts.UserDir, 0755); err != nil {
+		return fmt.Errorf("could not create user applications directory: %w", err)
+	}
+
+	info := hostenv.Detect()
+	session := hostenv.SessionEnv()
+
+	for _, browser := range config.Browsers {
+		if !opts.includesBrowser(browser.Name) {
+			continue
+		}
+
+		combinedFlags := computeBrowserFlags(browser, config, info, session)
+		if len(combinedFlags) == 0 {
+			if !opts.Quiet {
+				fmt.Printf("⚠️  No flags configured for %s, skipping...\n", browser.Name)
+			}
+			continue
+		}
+
+		var filenames []string
+		filenames = append(filenames, browser.DesktopFiles...)
+
+		if len(browser.PWAPatterns) > 0 {
+			pwaFiles, err := findPWADesktopFiles(opts.UserDir, browser.PWAPatterns, browser.ExcludePatterns)
+			if err != nil {
+				fmt.Printf("⚠️  Warning: Error finding %s PWA files: %v\n", browser.Name, err)
+			} else {
+				filenames = append(filenames, pwaFiles...)
+			}
+		}
+
+		for _, filename := range filenames {
+			result, err := processDesktopFile(opts, filename, combinedFlags)
+			if err != nil {
+				fmt.Printf("⚠️  Warning: Could not process %s: %v\n", filename, err)
+				continue
+			}
+			if result.Action == ActionSkippedNoSource {
+				continue
+			}
+			fn(browser, filename, result)
+		}
+	}
+	return nil
+}
+
+func loadConfigOrExit() AppConfig {
+	config, err := loadConfiguration(opts.ConfigPath)
+	if err != nil {
+		fmt.Printf("❌ Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	return config
+}
@@ -0,0 +1,45 @@
+package main
+
+// Options carries the runtime settings that used to be read from globals
+// (os.Getenv, hardcoded paths, fmt.Println side effects). Every subcommand
+// builds one of these from its flags and threads it through instead of
+// touching the environment directly, so apply/dry-run/diff can share the
+// exact same code path.
+type Options struct {
+	ConfigPath string
+	SystemDir  string
+	UserDir    string
+	StateDir   string // empty means state.DefaultBaseDir()
+	Verbose    bool
+	Quiet      bool
+	Only       string       // comma-separated browser names, empty means all
+	Portal     PortalPolicy // overrides every browser's configured PortalPolicy when set
+}
+
+// includesBrowser reports whether the --only filter (if any) selects the
+// given browser name.
+func (o Options) includesBrowser(name string) bool {
+	if o.Only == "" {
+		return true
+	}
+	for _, want := range splitCSV(o.Only) {
+		if want == name {
+			return true
+		}
+	}
+	return false
+}
+
+func splitCSV(s string) []string {
+	var out []string
+	start := 0
+	for i := 0; i <= len(s); i++ {
+		if i == len(s) || s[i] == ',' {
+			if i > start {
+				out = append(out, s[start:i])
+			}
+			start = i + 1
+		}
+	}
+	return out
+}
@@ -0,0 +1,178 @@
+package desktop
+
+import "strings"
+
+// ApplyFlags rewrites an Exec= command line so it launches with flags
+// applied, choosing how based on the launcher that actually starts the
+// browser: a plain binary, a Flatpak wrapper, or a Snap wrapper each take
+// flags in a different place.
+func ApplyFlags(execValue string, flags []string) string {
+	tokens := SplitExecTokens(execValue)
+	if len(tokens) == 0 {
+		return execValue
+	}
+
+	switch detectLauncherKind(tokens) {
+	case LauncherFlatpak:
+		return applyFlatpakFlags(tokens, flags)
+	case LauncherSnap:
+		return applySnapFlags(tokens, flags)
+	default:
+		return applyPlainFlags(tokens, flags)
+	}
+}
+
+// applyPlainFlags removes any existing occurrence of each new flag (matched
+// on its "--name" prefix, ignoring any "=value") and inserts the new flags
+// after the executable and any existing leading flags, before the first
+// field code or other positional argument — so "%U"/"%f" and explicit URLs
+// keep their place at the end of the command line. A leading "env
+// NAME=value..." wrapper (some .desktop files set env vars this way before
+// the real binary) is treated as part of the prefix, not the executable,
+// so flags land after the real command instead of between env and its
+// assignments.
+func applyPlainFlags(tokens []string, flags []string) string {
+	envPrefix, rest := splitEnvPrefix(tokens)
+	if len(rest) == 0 {
+		return JoinExecTokens(tokens)
+	}
+	executable := rest[0]
+	args := rest[1:]
+
+	for _, flag := range flags {
+		base := strings.SplitN(flag, "=", 2)[0]
+		args = removeFlagToken(args, base)
+	}
+
+	insertIndex := 0
+	for i, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			break
+		}
+		insertIndex = i + 1
+	}
+
+	newArgs := make([]string, 0, len(args)+len(flags))
+	newArgs = append(newArgs, args[:insertIndex]...)
+	newArgs = append(newArgs, flags...)
+	newArgs = append(newArgs, args[insertIndex:]...)
+
+	result := make([]string, 0, len(envPrefix)+1+len(newArgs))
+	result = append(result, envPrefix...)
+	result = append(result, executable)
+	result = append(result, newArgs...)
+	return JoinExecTokens(result)
+}
+
+// splitEnvPrefix peels a leading "env NAME=value..." wrapper off tokens, so
+// the caller can find the actual executable instead of treating "env" as
+// one. Returns the wrapper (possibly empty) and the remaining tokens.
+func splitEnvPrefix(tokens []string) (prefix, rest []string) {
+	if len(tokens) == 0 || tokens[0] != "env" {
+		return nil, tokens
+	}
+	i := 1
+	for i < len(tokens) && isEnvAssignment(tokens[i]) {
+		i++
+	}
+	return tokens[:i], tokens[i:]
+}
+
+// isEnvAssignment reports whether token looks like a shell "NAME=value"
+// environment assignment rather than a command or argument.
+func isEnvAssignment(token string) bool {
+	eq := strings.IndexByte(token, '=')
+	if eq <= 0 {
+		return false
+	}
+	for _, r := range token[:eq] {
+		if r != '_' && !('a' <= r && r <= 'z') && !('A' <= r && r <= 'Z') && !('0' <= r && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// applyFlatpakFlags rewrites "flatpak run [OPTION...] APP_ID [ARG...]" by
+// passing the flags through a single "--env=CHROMIUM_FLAGS=..." option,
+// since Flatpak sandboxes the app and won't forward bare browser flags to
+// it directly. Any prior CHROMIUM_FLAGS option is replaced so re-running
+// apply stays idempotent.
+func applyFlatpakFlags(tokens []string, flags []string) string {
+	rest := tokens[2:] // past "flatpak" "run"
+
+	appIndex := 0
+	for appIndex < len(rest) && strings.HasPrefix(rest[appIndex], "-") {
+		appIndex++
+	}
+	flatpakOpts := removeEnvFlag(rest[:appIndex], "CHROMIUM_FLAGS")
+	appAndArgs := rest[appIndex:]
+
+	newTokens := make([]string, 0, len(tokens)+1)
+	newTokens = append(newTokens, tokens[0], tokens[1])
+	newTokens = append(newTokens, flatpakOpts...)
+	newTokens = append(newTokens, "--env=CHROMIUM_FLAGS="+strings.Join(flags, " "))
+	newTokens = append(newTokens, appAndArgs...)
+
+	return JoinExecTokens(newTokens)
+}
+
+// applySnapFlags rewrites a Snap-confined command by passing flags after a
+// "--" separator, which is how snap's command wrappers forward arguments to
+// the confined binary instead of to snap itself.
+func applySnapFlags(tokens []string, flags []string) string {
+	executable := tokens[0]
+	args := tokens[1:]
+
+	sepIndex := indexOfToken(args, "--")
+	preSep, postSep := args, []string(nil)
+	if sepIndex != -1 {
+		preSep, postSep = args[:sepIndex], args[sepIndex+1:]
+	}
+
+	for _, flag := range flags {
+		base := strings.SplitN(flag, "=", 2)[0]
+		postSep = removeFlagToken(postSep, base)
+	}
+
+	newTokens := make([]string, 0, len(tokens)+len(flags)+1)
+	newTokens = append(newTokens, executable)
+	newTokens = append(newTokens, preSep...)
+	newTokens = append(newTokens, "--")
+	newTokens = append(newTokens, flags...)
+	newTokens = append(newTokens, postSep...)
+
+	return JoinExecTokens(newTokens)
+}
+
+func removeFlagToken(args []string, base string) []string {
+	out := args[:0:0]
+	for _, arg := range args {
+		if arg == base || strings.HasPrefix(arg, base+"=") {
+			continue
+		}
+		out = append(out, arg)
+	}
+	return out
+}
+
+func removeEnvFlag(opts []string, envName string) []string {
+	prefix := "--env=" + envName + "="
+	out := opts[:0:0]
+	for _, opt := range opts {
+		if strings.HasPrefix(opt, prefix) {
+			continue
+		}
+		out = append(out, opt)
+	}
+	return out
+}
+
+func indexOfToken(tokens []string, target string) int {
+	for i, token := range tokens {
+		if token == target {
+			return i
+		}
+	}
+	return -1
+}
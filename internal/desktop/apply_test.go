@@ -0,0 +1,39 @@
+package desktop
+
+import "testing"
+
+func TestApplyFlagsPlainSkipsEnvPrefix(t *testing.T) {
+	got := ApplyFlags(`env SOMEVAR="hello world" /usr/bin/code %F`, []string{"--ozone-platform=wayland"})
+	want := `env "SOMEVAR=hello world" /usr/bin/code --ozone-platform=wayland %F`
+	if got != want {
+		t.Errorf("ApplyFlags = %q, want %q", got, want)
+	}
+	// The flag must land after the real command, not inside the env prefix.
+	if got == `env --ozone-platform=wayland "SOMEVAR=hello world" /usr/bin/code %F` {
+		t.Errorf("flag was inserted inside the env VAR=val prefix: %q", got)
+	}
+}
+
+func TestApplyFlagsPlainReplacesExistingFlag(t *testing.T) {
+	got := ApplyFlags("/usr/bin/code --ozone-platform=x11 %F", []string{"--ozone-platform=wayland"})
+	want := "/usr/bin/code --ozone-platform=wayland %F"
+	if got != want {
+		t.Errorf("ApplyFlags = %q, want %q", got, want)
+	}
+}
+
+func TestApplyFlagsFlatpak(t *testing.T) {
+	got := ApplyFlags("flatpak run com.brave.Browser %U", []string{"--ozone-platform=wayland"})
+	want := "flatpak run --env=CHROMIUM_FLAGS=--ozone-platform=wayland com.brave.Browser %U"
+	if got != want {
+		t.Errorf("ApplyFlags = %q, want %q", got, want)
+	}
+}
+
+func TestApplyFlagsSnap(t *testing.T) {
+	got := ApplyFlags("/snap/bin/brave %U", []string{"--ozone-platform=wayland"})
+	want := "/snap/bin/brave %U -- --ozone-platform=wayland"
+	if got != want {
+		t.Errorf("ApplyFlags = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,157 @@
+// Package desktop implements enough of the freedesktop.org Desktop Entry
+// Specification to safely rewrite the Exec= line(s) of a .desktop file —
+// including the ones inside [Desktop Action ...] groups — without
+// disturbing anything else in the file.
+package desktop
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// LineKind classifies a single physical line of a .desktop file.
+type LineKind int
+
+const (
+	LineBlank LineKind = iota
+	LineComment
+	LineGroupHeader
+	LineKeyValue
+	LineUnrecognized // doesn't parse as any of the above; kept verbatim
+)
+
+// Line is one physical line, keeping enough structure to edit a key/value
+// pair while leaving everything else (including its exact original text)
+// untouched.
+type Line struct {
+	Kind  LineKind
+	Raw   string // original text, sans trailing newline
+	Group string // the "[Group Name]" this line belongs to
+
+	// Populated only when Kind == LineGroupHeader.
+	GroupName string
+
+	// Populated only when Kind == LineKeyValue.
+	Key    string
+	Locale string // e.g. "es_ES" from "Key[es_ES]=Value"; empty if unlocalized
+	Value  string
+}
+
+// Render reconstructs the line's original text, reflecting any edits made
+// to Value.
+func (l *Line) Render() string {
+	switch l.Kind {
+	case LineGroupHeader:
+		return "[" + l.GroupName + "]"
+	case LineKeyValue:
+		key := l.Key
+		if l.Locale != "" {
+			key += "[" + l.Locale + "]"
+		}
+		return key + "=" + l.Value
+	default:
+		return l.Raw
+	}
+}
+
+var (
+	groupHeaderPattern = regexp.MustCompile(`^\[(.+)\]$`)
+	keyValuePattern    = regexp.MustCompile(`^([A-Za-z0-9-]+)(?:\[([^\]]+)\])?=(.*)$`)
+)
+
+// Entry is a parsed .desktop file: every line in original order, so
+// unrelated content (comments, blank lines, key ordering) round-trips
+// byte-for-byte when nothing is changed.
+type Entry struct {
+	Lines []*Line
+
+	// Newline is the line terminator found in the source file ("\n" or
+	// "\r\n"), and FinalNewline records whether the source ended with one.
+	// Bytes uses both so an unchanged file round-trips byte-for-byte instead
+	// of being silently normalized to LF-with-trailing-newline.
+	Newline      string
+	FinalNewline bool
+}
+
+// Parse reads a .desktop file's content into an Entry.
+func Parse(content []byte) (*Entry, error) {
+	newline := "\n"
+	if bytes.Contains(content, []byte("\r\n")) {
+		newline = "\r\n"
+	}
+	entry := &Entry{
+		Newline:      newline,
+		FinalNewline: len(content) == 0 || bytes.HasSuffix(content, []byte(newline)),
+	}
+	currentGroup := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		raw := scanner.Text()
+		trimmed := strings.TrimSpace(raw)
+
+		line := &Line{Raw: raw, Group: currentGroup}
+		switch {
+		case trimmed == "":
+			line.Kind = LineBlank
+		case strings.HasPrefix(trimmed, "#"):
+			line.Kind = LineComment
+		case groupHeaderPattern.MatchString(trimmed):
+			matches := groupHeaderPattern.FindStringSubmatch(trimmed)
+			line.Kind = LineGroupHeader
+			line.GroupName = matches[1]
+			currentGroup = matches[1]
+			line.Group = currentGroup
+		case keyValuePattern.MatchString(raw):
+			matches := keyValuePattern.FindStringSubmatch(raw)
+			line.Kind = LineKeyValue
+			line.Key = matches[1]
+			line.Locale = matches[2]
+			line.Value = matches[3]
+		default:
+			line.Kind = LineUnrecognized
+		}
+
+		entry.Lines = append(entry.Lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not parse desktop entry: %w", err)
+	}
+	return entry, nil
+}
+
+// Bytes renders the entry back to its on-disk form, using the original
+// line terminator and trailing-newline presence recorded by Parse.
+func (e *Entry) Bytes() []byte {
+	newline := e.Newline
+	if newline == "" {
+		newline = "\n"
+	}
+	var out bytes.Buffer
+	for i, line := range e.Lines {
+		out.WriteString(line.Render())
+		if i < len(e.Lines)-1 || e.FinalNewline {
+			out.WriteString(newline)
+		}
+	}
+	return out.Bytes()
+}
+
+// EachExec calls fn with the group name ("Desktop Entry", "Desktop Action
+// new-window", ...) and current value of every unlocalized Exec= line in the
+// file, replacing it with fn's return value. Returns how many lines it
+// touched.
+func (e *Entry) EachExec(fn func(group, exec string) string) int {
+	count := 0
+	for _, line := range e.Lines {
+		if line.Kind != LineKeyValue || line.Key != "Exec" || line.Locale != "" {
+			continue
+		}
+		count++
+		line.Value = fn(line.Group, line.Value)
+	}
+	return count
+}
@@ -0,0 +1,71 @@
+package desktop
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseBytesRoundTripsLFWithFinalNewline(t *testing.T) {
+	content := []byte("[Desktop Entry]\nName=Code\nExec=/usr/bin/code %F\n")
+	entry, err := Parse(content)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if got := entry.Bytes(); !bytes.Equal(got, content) {
+		t.Errorf("Bytes() = %q, want %q", got, content)
+	}
+}
+
+func TestParseBytesRoundTripsMissingFinalNewline(t *testing.T) {
+	content := []byte("[Desktop Entry]\nName=Code\nExec=/usr/bin/code %F")
+	entry, err := Parse(content)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if got := entry.Bytes(); !bytes.Equal(got, content) {
+		t.Errorf("Bytes() = %q, want %q", got, content)
+	}
+}
+
+func TestParseBytesRoundTripsCRLF(t *testing.T) {
+	content := []byte("[Desktop Entry]\r\nName=Code\r\nExec=/usr/bin/code %F\r\n")
+	entry, err := Parse(content)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if entry.Newline != "\r\n" {
+		t.Errorf("Newline = %q, want %q", entry.Newline, "\r\n")
+	}
+	if got := entry.Bytes(); !bytes.Equal(got, content) {
+		t.Errorf("Bytes() = %q, want %q", got, content)
+	}
+}
+
+func TestParseBytesRoundTripsCRLFMissingFinalNewline(t *testing.T) {
+	content := []byte("[Desktop Entry]\r\nName=Code\r\nExec=/usr/bin/code %F")
+	entry, err := Parse(content)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if entry.FinalNewline {
+		t.Error("expected FinalNewline to be false")
+	}
+	if got := entry.Bytes(); !bytes.Equal(got, content) {
+		t.Errorf("Bytes() = %q, want %q", got, content)
+	}
+}
+
+func TestParseBytesAppliesEditsWithPreservedTerminator(t *testing.T) {
+	content := []byte("[Desktop Entry]\r\nExec=/usr/bin/code %F")
+	entry, err := Parse(content)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	entry.EachExec(func(group, exec string) string {
+		return exec + " --ozone-platform=wayland"
+	})
+	want := []byte("[Desktop Entry]\r\nExec=/usr/bin/code %F --ozone-platform=wayland")
+	if got := entry.Bytes(); !bytes.Equal(got, want) {
+		t.Errorf("Bytes() = %q, want %q", got, want)
+	}
+}
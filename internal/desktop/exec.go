@@ -0,0 +1,93 @@
+package desktop
+
+import (
+	"regexp"
+	"strings"
+)
+
+// fieldCodePattern matches a standalone Exec field code (%f, %F, %u, %U, %c,
+// %k, %i, %d, %D, %n, %N, %v, %m, %%). These substitute file/URL/icon
+// arguments at launch time and must never be quoted or have their % escaped.
+var fieldCodePattern = regexp.MustCompile(`^%[fFuUckidDnNvm%]$`)
+
+// execReserved is the set of characters the spec requires quoting or
+// escaping for, when they appear in an argument that isn't a field code.
+const execReserved = " \t\n\"'\\`$()*?|&;<>#~[]"
+
+// SplitExecTokens tokenizes an Exec= value into its arguments, honoring the
+// Desktop Entry Specification's quoting rules: inside double quotes, only
+// \\, \$, \`, \" and \newline are escapes; outside quotes, a backslash
+// escapes the following reserved character; unquoted whitespace separates
+// arguments.
+func SplitExecTokens(exec string) []string {
+	var tokens []string
+	var current strings.Builder
+	hasToken := false
+	inQuotes := false
+
+	runes := []rune(exec)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case inQuotes:
+			if r == '"' {
+				inQuotes = false
+				continue
+			}
+			if r == '\\' && i+1 < len(runes) && strings.ContainsRune(`"\\$`+"`", runes[i+1]) {
+				i++
+				current.WriteRune(runes[i])
+				continue
+			}
+			current.WriteRune(r)
+		case r == '"':
+			inQuotes = true
+			hasToken = true
+		case r == '\\' && i+1 < len(runes):
+			i++
+			current.WriteRune(runes[i])
+			hasToken = true
+		case r == ' ' || r == '\t':
+			if hasToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				hasToken = false
+			}
+		default:
+			current.WriteRune(r)
+			hasToken = true
+		}
+	}
+	if hasToken {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
+
+// quoteExecToken renders a single argument back into Exec= syntax, quoting
+// it only when it contains a reserved character, and leaving bare field
+// codes completely untouched.
+func quoteExecToken(token string) string {
+	if fieldCodePattern.MatchString(token) {
+		return token
+	}
+	if token == "" || strings.ContainsAny(token, execReserved) {
+		escaped := strings.NewReplacer(
+			`\`, `\\`,
+			`"`, `\"`,
+			"`", "\\`",
+			`$`, `\$`,
+		).Replace(token)
+		return `"` + escaped + `"`
+	}
+	return token
+}
+
+// JoinExecTokens reassembles arguments into an Exec= value.
+func JoinExecTokens(tokens []string) string {
+	quoted := make([]string, len(tokens))
+	for i, token := range tokens {
+		quoted[i] = quoteExecToken(token)
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,69 @@
+package desktop
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitExecTokens(t *testing.T) {
+	tests := []struct {
+		name string
+		exec string
+		want []string
+	}{
+		{
+			name: "plain binary and field code",
+			exec: "/usr/bin/code %F",
+			want: []string{"/usr/bin/code", "%F"},
+		},
+		{
+			name: "quoted argument with spaces",
+			exec: `env SOMEVAR="hello world" /usr/bin/code %F`,
+			want: []string{"env", "SOMEVAR=hello world", "/usr/bin/code", "%F"},
+		},
+		{
+			name: "escaped quote inside quoted argument",
+			exec: `myapp "say \"hi\""`,
+			want: []string{"myapp", `say "hi"`},
+		},
+		{
+			name: "backslash escape outside quotes",
+			exec: `myapp foo\ bar`,
+			want: []string{"myapp", "foo bar"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitExecTokens(tt.exec)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitExecTokens(%q) = %#v, want %#v", tt.exec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinExecTokensRoundTrip(t *testing.T) {
+	tests := []string{
+		"/usr/bin/code %F",
+		`env SOMEVAR="hello world" /usr/bin/code %F`,
+		`myapp "say \"hi\""`,
+	}
+
+	for _, exec := range tests {
+		tokens := SplitExecTokens(exec)
+		rejoined := JoinExecTokens(tokens)
+		again := SplitExecTokens(rejoined)
+		if !reflect.DeepEqual(tokens, again) {
+			t.Errorf("round-trip changed tokens for %q: %#v -> %q -> %#v", exec, tokens, rejoined, again)
+		}
+	}
+}
+
+func TestJoinExecTokensLeavesFieldCodesUnquoted(t *testing.T) {
+	got := JoinExecTokens([]string{"/usr/bin/code", "%F"})
+	want := "/usr/bin/code %F"
+	if got != want {
+		t.Errorf("JoinExecTokens = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,27 @@
+package desktop
+
+import "strings"
+
+// LauncherKind is how an Exec= command line actually launches the browser,
+// which changes where flags need to go.
+type LauncherKind int
+
+const (
+	LauncherPlain LauncherKind = iota
+	LauncherFlatpak
+	LauncherSnap
+)
+
+// detectLauncherKind inspects the already-tokenized Exec= command.
+func detectLauncherKind(tokens []string) LauncherKind {
+	if len(tokens) == 0 {
+		return LauncherPlain
+	}
+	if tokens[0] == "flatpak" && len(tokens) > 1 && tokens[1] == "run" {
+		return LauncherFlatpak
+	}
+	if strings.HasPrefix(tokens[0], "/snap/bin/") {
+		return LauncherSnap
+	}
+	return LauncherPlain
+}
@@ -0,0 +1,85 @@
+// Package hostenv detects facts about the machine wailandify is running on
+// (session type, desktop environment, GPU vendor, kernel version) so a
+// FlagSet can declare it only applies on hosts that match.
+package hostenv
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Info is a snapshot of the parts of the host environment flag sets may
+// condition on.
+type Info struct {
+	SessionType    string   // $XDG_SESSION_TYPE, lowercased (wayland, x11, tty, ...)
+	CurrentDesktop string   // $XDG_CURRENT_DESKTOP, lowercased (gnome, kde, ...)
+	KernelVersion  string   // `uname -r` output, e.g. "6.9.3-arch1-1"
+	GPUVendors     []string // "intel", "amd", "nvidia" for every /sys/class/drm card found
+}
+
+// pciVendorNames maps the vendor IDs exposed under
+// /sys/class/drm/*/device/vendor to the names FlagSet predicates use.
+var pciVendorNames = map[string]string{
+	"0x8086": "intel",
+	"0x1002": "amd",
+	"0x1022": "amd",
+	"0x10de": "nvidia",
+}
+
+// Detect reads the current session's environment, kernel version, and GPU
+// vendor(s). Detection failures (missing /sys, no `uname`) are silently
+// treated as "unknown" rather than errors, since predicates should degrade
+// to not-matching instead of crashing the tool.
+func Detect() Info {
+	return Info{
+		SessionType:    strings.ToLower(os.Getenv("XDG_SESSION_TYPE")),
+		CurrentDesktop: strings.ToLower(os.Getenv("XDG_CURRENT_DESKTOP")),
+		KernelVersion:  detectKernelVersion(),
+		GPUVendors:     detectGPUVendors(),
+	}
+}
+
+func detectKernelVersion() string {
+	out, err := exec.Command("uname", "-r").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func detectGPUVendors() []string {
+	cards, err := filepath.Glob("/sys/class/drm/card[0-9]*/device/vendor")
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var vendors []string
+	for _, path := range cards {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		id := strings.TrimSpace(string(raw))
+		name, ok := pciVendorNames[id]
+		if !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+		vendors = append(vendors, name)
+	}
+	return vendors
+}
+
+// HasGPUVendor reports whether vendor (e.g. "intel") is among the detected
+// GPUs.
+func (i Info) HasGPUVendor(vendor string) bool {
+	for _, v := range i.GPUVendors {
+		if v == vendor {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package hostenv
+
+import "path/filepath"
+
+// Predicate gates a FlagSet to hosts matching all of its non-empty fields.
+// Each field is matched as a shell glob (path/filepath.Match) against the
+// corresponding Info field; an empty field always matches. GPUVendor matches
+// if any detected GPU vendor matches the glob.
+type Predicate struct {
+	SessionType    string `json:"sessionType,omitempty"`
+	CurrentDesktop string `json:"desktop,omitempty"`
+	KernelVersion  string `json:"kernelVersion,omitempty"`
+	GPUVendor      string `json:"gpuVendor,omitempty"`
+}
+
+// Matches reports whether every non-empty field of p matches info.
+func (p Predicate) Matches(info Info) bool {
+	if p.SessionType != "" && !globMatch(p.SessionType, info.SessionType) {
+		return false
+	}
+	if p.CurrentDesktop != "" && !globMatch(p.CurrentDesktop, info.CurrentDesktop) {
+		return false
+	}
+	if p.KernelVersion != "" && !globMatch(p.KernelVersion, info.KernelVersion) {
+		return false
+	}
+	if p.GPUVendor != "" {
+		matched := false
+		for _, vendor := range info.GPUVendors {
+			if globMatch(p.GPUVendor, vendor) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func globMatch(pattern, value string) bool {
+	matched, err := filepath.Match(pattern, value)
+	return err == nil && matched
+}
@@ -0,0 +1,40 @@
+package hostenv
+
+import "testing"
+
+func TestPredicateMatchesEmptyFieldsAlwaysMatch(t *testing.T) {
+	p := Predicate{}
+	if !p.Matches(Info{}) {
+		t.Error("an empty predicate should match any Info")
+	}
+}
+
+func TestPredicateMatchesGlobFields(t *testing.T) {
+	p := Predicate{CurrentDesktop: "gnome"}
+	if !p.Matches(Info{CurrentDesktop: "gnome"}) {
+		t.Error("expected exact desktop match to match")
+	}
+	if p.Matches(Info{CurrentDesktop: "kde"}) {
+		t.Error("expected mismatched desktop not to match")
+	}
+}
+
+func TestPredicateMatchesGPUVendorAgainstAnyDetectedGPU(t *testing.T) {
+	p := Predicate{GPUVendor: "intel"}
+	if !p.Matches(Info{GPUVendors: []string{"amd", "intel"}}) {
+		t.Error("expected GPUVendor to match if any detected vendor matches")
+	}
+	if p.Matches(Info{GPUVendors: []string{"amd"}}) {
+		t.Error("expected GPUVendor not to match when no detected vendor matches")
+	}
+}
+
+func TestPredicateMatchesRequiresAllNonEmptyFields(t *testing.T) {
+	p := Predicate{CurrentDesktop: "gnome", GPUVendor: "intel"}
+	if p.Matches(Info{CurrentDesktop: "gnome", GPUVendors: []string{"amd"}}) {
+		t.Error("expected a mismatch on one field to fail the whole predicate")
+	}
+	if !p.Matches(Info{CurrentDesktop: "gnome", GPUVendors: []string{"intel"}}) {
+		t.Error("expected a match on every non-empty field to succeed")
+	}
+}
@@ -0,0 +1,48 @@
+package hostenv
+
+import (
+	"os"
+	"strings"
+)
+
+// SessionClass classifies the graphical session a browser is about to be
+// launched into, distinct from Info/Predicate: it's a small, named set of
+// classes meant for a FlagSet's "when" field, rather than arbitrary
+// host-matching globs.
+type SessionClass string
+
+const (
+	SessionWaylandGnome SessionClass = "wayland-gnome"
+	SessionWaylandKDE   SessionClass = "wayland-kde"
+	SessionWaylandOther SessionClass = "wayland-other"
+	SessionX11          SessionClass = "x11"
+	SessionUnknown      SessionClass = "unknown"
+)
+
+// IsWayland reports whether the session is any of the wayland-* classes.
+func (s SessionClass) IsWayland() bool {
+	return strings.HasPrefix(string(s), "wayland-")
+}
+
+// SessionEnv classifies the current session by inspecting XDG_SESSION_TYPE,
+// WAYLAND_DISPLAY, XDG_CURRENT_DESKTOP, and DISPLAY, in that order of trust.
+func SessionEnv() SessionClass {
+	sessionType := strings.ToLower(os.Getenv("XDG_SESSION_TYPE"))
+	isWayland := sessionType == "wayland" || os.Getenv("WAYLAND_DISPLAY") != ""
+	isX11 := sessionType == "x11" || os.Getenv("DISPLAY") != ""
+
+	if isWayland {
+		switch desktop := strings.ToLower(os.Getenv("XDG_CURRENT_DESKTOP")); {
+		case strings.Contains(desktop, "gnome"):
+			return SessionWaylandGnome
+		case strings.Contains(desktop, "kde"):
+			return SessionWaylandKDE
+		default:
+			return SessionWaylandOther
+		}
+	}
+	if isX11 {
+		return SessionX11
+	}
+	return SessionUnknown
+}
@@ -0,0 +1,49 @@
+package hostenv
+
+import "testing"
+
+func setSessionEnv(t *testing.T, sessionType, waylandDisplay, currentDesktop, display string) {
+	t.Setenv("XDG_SESSION_TYPE", sessionType)
+	t.Setenv("WAYLAND_DISPLAY", waylandDisplay)
+	t.Setenv("XDG_CURRENT_DESKTOP", currentDesktop)
+	t.Setenv("DISPLAY", display)
+}
+
+func TestSessionEnv(t *testing.T) {
+	tests := []struct {
+		name                                                 string
+		sessionType, waylandDisplay, currentDesktop, display string
+		want                                                 SessionClass
+	}{
+		{"wayland gnome", "wayland", "wayland-0", "GNOME", "", SessionWaylandGnome},
+		{"wayland kde", "wayland", "wayland-0", "KDE", "", SessionWaylandKDE},
+		{"wayland other desktop", "wayland", "wayland-0", "Sway", "", SessionWaylandOther},
+		{"wayland via display var only", "", "wayland-0", "GNOME", "", SessionWaylandGnome},
+		{"x11 via session type", "x11", "", "GNOME", ":0", SessionX11},
+		{"x11 via display var only", "", "", "", ":0", SessionX11},
+		{"unknown with nothing set", "", "", "", "", SessionUnknown},
+		{"wayland wins over stray DISPLAY", "wayland", "wayland-0", "GNOME", ":0", SessionWaylandGnome},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setSessionEnv(t, tt.sessionType, tt.waylandDisplay, tt.currentDesktop, tt.display)
+			if got := SessionEnv(); got != tt.want {
+				t.Errorf("SessionEnv() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionClassIsWayland(t *testing.T) {
+	for _, class := range []SessionClass{SessionWaylandGnome, SessionWaylandKDE, SessionWaylandOther} {
+		if !class.IsWayland() {
+			t.Errorf("%q.IsWayland() = false, want true", class)
+		}
+	}
+	for _, class := range []SessionClass{SessionX11, SessionUnknown} {
+		if class.IsWayland() {
+			t.Errorf("%q.IsWayland() = true, want false", class)
+		}
+	}
+}
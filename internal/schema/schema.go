@@ -0,0 +1,69 @@
+// Package schema holds the JSON Schema for wailandify's config.json and a
+// lightweight validator that checks a document's shape against it.
+package schema
+
+// JSON is the draft 2020-12 JSON Schema for config.json, kept in lockstep
+// with AppConfig/FlagSet/BrowserConfig in cmd/wailandify/config.go by hand.
+// `wailandify schema` prints it so users can point an editor's "$schema" at
+// a local copy, and ValidateConfig parses it back in to drive its checks,
+// so the two can't drift apart the way a second hand-written field list
+// would.
+const JSON = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "https://github.com/jassielof/wailandify/schema/config.json",
+  "title": "wailandify config.json",
+  "type": "object",
+  "additionalProperties": false,
+  "properties": {
+    "$schema": { "type": "string" },
+    "flagSets": {
+      "type": "object",
+      "additionalProperties": { "$ref": "#/$defs/flagSet" }
+    },
+    "browsers": {
+      "type": "array",
+      "items": { "$ref": "#/$defs/browser" }
+    }
+  },
+  "$defs": {
+    "flagSet": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "name": { "type": "string" },
+        "flags": { "type": "array", "items": { "type": "string" } },
+        "appliesWhen": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "sessionType": { "type": "string" },
+            "desktop": { "type": "string" },
+            "kernelVersion": { "type": "string" },
+            "gpuVendor": { "type": "string" }
+          }
+        },
+        "when": {
+          "type": "string",
+          "enum": ["wayland-gnome", "wayland-kde", "wayland-other", "x11", "unknown"]
+        }
+      }
+    },
+    "browser": {
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["name"],
+      "properties": {
+        "name": { "type": "string" },
+        "description": { "type": "string" },
+        "desktopFiles": { "type": "array", "items": { "type": "string" } },
+        "pwaPatterns": { "type": "array", "items": { "type": "string" } },
+        "excludePatterns": { "type": "array", "items": { "type": "string" } },
+        "flagSets": { "type": "array", "items": { "type": "string" } },
+        "portalPolicy": { "type": "string", "enum": ["auto", "prefer", "disable"] },
+        "flagFiles": { "type": "array", "items": { "type": "string" } },
+        "pipewireCapture": { "type": "boolean" }
+      }
+    }
+  }
+}
+`
@@ -0,0 +1,241 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Issue is one problem found in a config document, with enough position
+// information to jump to it in an editor.
+type Issue struct {
+	Path    string // dotted/indexed locator, e.g. "$.browsers[2].flagset"
+	Line    int
+	Column  int
+	Message string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%d:%d: %s: %s", i.Line, i.Column, i.Path, i.Message)
+}
+
+// schemaDoc is JSON parsed back into a generic tree once at init, so
+// ValidateConfig can check a document's fields and enums directly against
+// it instead of against a second, hand-written list that could drift.
+var schemaDoc = mustParseSchema(JSON)
+
+func mustParseSchema(raw string) map[string]any {
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(raw), &doc); err != nil {
+		panic("schema: embedded JSON Schema does not parse: " + err.Error())
+	}
+	return doc
+}
+
+func schemaDefs() map[string]any {
+	defs, _ := schemaDoc["$defs"].(map[string]any)
+	return defs
+}
+
+func schemaDef(name string) map[string]any {
+	def, _ := schemaDefs()[name].(map[string]any)
+	return def
+}
+
+func schemaProperties(node map[string]any) map[string]any {
+	props, _ := node["properties"].(map[string]any)
+	return props
+}
+
+func schemaRequired(node map[string]any) []string {
+	raw, _ := node["required"].([]any)
+	required := make([]string, 0, len(raw))
+	for _, r := range raw {
+		if s, ok := r.(string); ok {
+			required = append(required, s)
+		}
+	}
+	return required
+}
+
+// ValidateConfig checks a full config.json (or a config.d/*.json fragment,
+// which shares its shape) against the embedded JSON Schema, catching typos
+// like "flagSet" and unknown fields json.Unmarshal would otherwise silently
+// ignore, as well as values outside a field's documented enum (e.g. a
+// misspelled "portalPolicy").
+func ValidateConfig(raw []byte) ([]Issue, error) {
+	obj, err := decodeObject(raw)
+	if err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		return []Issue{{Path: "$", Line: 1, Column: 1, Message: "top-level value must be an object"}}, nil
+	}
+
+	var issues []Issue
+	issues = append(issues, checkFields(raw, "$", obj, schemaProperties(schemaDoc))...)
+
+	if flagSets, ok := obj["flagSets"].(map[string]any); ok {
+		issues = append(issues, validateFlagSets(raw, "$.flagSets.", flagSets)...)
+	}
+	if browsers, ok := obj["browsers"].([]any); ok {
+		issues = append(issues, validateBrowsers(raw, "$.browsers", browsers)...)
+	}
+	return issues, nil
+}
+
+// ValidateFlagSetsFragment checks a flagsets.d/*.json file, which is a bare
+// map of flag set name to FlagSet rather than a full config.json.
+func ValidateFlagSetsFragment(raw []byte) ([]Issue, error) {
+	obj, err := decodeObject(raw)
+	if err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		return []Issue{{Path: "$", Line: 1, Column: 1, Message: "flag set fragment must be an object of name -> flag set"}}, nil
+	}
+	return validateFlagSets(raw, "$.", obj), nil
+}
+
+// ValidateBrowsersFragment checks a browsers.d/*.json file, a bare array of
+// BrowserConfig rather than a full config.json.
+func ValidateBrowsersFragment(raw []byte) ([]Issue, error) {
+	var root any
+	if err := json.Unmarshal(raw, &root); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	arr, ok := root.([]any)
+	if !ok {
+		return []Issue{{Path: "$", Line: 1, Column: 1, Message: "browsers fragment must be an array"}}, nil
+	}
+	return validateBrowsers(raw, "$", arr), nil
+}
+
+func validateFlagSets(raw []byte, pathPrefix string, flagSets map[string]any) []Issue {
+	props := schemaProperties(schemaDef("flagSet"))
+
+	var issues []Issue
+	for key, value := range flagSets {
+		path := pathPrefix + key
+		fsObj, ok := value.(map[string]any)
+		if !ok {
+			issues = append(issues, newIssue(raw, path, "flag set must be an object"))
+			continue
+		}
+		issues = append(issues, checkFields(raw, path, fsObj, props)...)
+		issues = append(issues, checkEnums(raw, path, fsObj, props)...)
+	}
+	return issues
+}
+
+func validateBrowsers(raw []byte, pathPrefix string, browsers []any) []Issue {
+	browserDef := schemaDef("browser")
+	props := schemaProperties(browserDef)
+	required := schemaRequired(browserDef)
+
+	var issues []Issue
+	for i, value := range browsers {
+		path := fmt.Sprintf("%s[%d]", pathPrefix, i)
+		bObj, ok := value.(map[string]any)
+		if !ok {
+			issues = append(issues, newIssue(raw, path, "browser must be an object"))
+			continue
+		}
+		issues = append(issues, checkFields(raw, path, bObj, props)...)
+		issues = append(issues, checkEnums(raw, path, bObj, props)...)
+		for _, field := range required {
+			if _, has := bObj[field]; !has {
+				issues = append(issues, newIssue(raw, path, fmt.Sprintf("missing required field %q", field)))
+			}
+		}
+	}
+	return issues
+}
+
+// checkFields flags any key in obj that isn't one of the schema's declared
+// properties for this node.
+func checkFields(raw []byte, path string, obj map[string]any, properties map[string]any) []Issue {
+	var issues []Issue
+	for key := range obj {
+		if _, ok := properties[key]; !ok {
+			issues = append(issues, newIssue(raw, path+"."+key, fmt.Sprintf("unknown field %q", key)))
+		}
+	}
+	return issues
+}
+
+// checkEnums flags string values that don't match their property's
+// schema "enum" (e.g. "when": "wayland-gonme" or "portalPolicy": "bogus") —
+// a typo that json.Unmarshal accepts and checkFields wouldn't catch, since
+// the field name itself is spelled correctly.
+func checkEnums(raw []byte, path string, obj map[string]any, properties map[string]any) []Issue {
+	var issues []Issue
+	for key, value := range obj {
+		propSchema, ok := properties[key].(map[string]any)
+		if !ok {
+			continue
+		}
+		enum, ok := propSchema["enum"].([]any)
+		if !ok {
+			continue
+		}
+		str, ok := value.(string)
+		if !ok {
+			continue
+		}
+		if !enumContains(enum, str) {
+			issues = append(issues, newIssue(raw, path+"."+key, fmt.Sprintf("%q is not a valid value for %q", str, key)))
+		}
+	}
+	return issues
+}
+
+func enumContains(enum []any, value string) bool {
+	for _, allowed := range enum {
+		if s, ok := allowed.(string); ok && s == value {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeObject(raw []byte) (map[string]any, error) {
+	var root any
+	if err := json.Unmarshal(raw, &root); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	obj, _ := root.(map[string]any)
+	return obj, nil
+}
+
+// newIssue locates the first occurrence of the issue's field key in raw to
+// report a line/column — precise enough for this shape check, without
+// needing a full streaming-decoder position tracker.
+func newIssue(raw []byte, path, message string) Issue {
+	line, col := locate(raw, path)
+	return Issue{Path: path, Line: line, Column: col, Message: message}
+}
+
+func locate(raw []byte, path string) (line, col int) {
+	needle := []byte(`"` + lastSegment(path) + `"`)
+	offset := bytes.Index(raw, needle)
+	if offset == -1 {
+		return 1, 1
+	}
+	line = 1 + bytes.Count(raw[:offset], []byte("\n"))
+	if nl := bytes.LastIndexByte(raw[:offset], '\n'); nl != -1 {
+		col = offset - nl
+	} else {
+		col = offset + 1
+	}
+	return line, col
+}
+
+func lastSegment(path string) string {
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i] == '.' {
+			return path[i+1:]
+		}
+	}
+	return path
+}
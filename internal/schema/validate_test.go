@@ -0,0 +1,131 @@
+package schema
+
+import "testing"
+
+func TestValidateConfigFlagsUnknownField(t *testing.T) {
+	raw := []byte(`{
+  "flagSets": {
+    "wayland_basic": {
+      "flagz": ["--ozone-platform=wayland"]
+    }
+  }
+}`)
+	issues, err := ValidateConfig(raw)
+	if err != nil {
+		t.Fatalf("ValidateConfig returned error: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d: %#v", len(issues), issues)
+	}
+	if issues[0].Path != "$.flagSets.wayland_basic.flagz" {
+		t.Errorf("Path = %q, want %q", issues[0].Path, "$.flagSets.wayland_basic.flagz")
+	}
+	if issues[0].Line != 4 {
+		t.Errorf("Line = %d, want 4", issues[0].Line)
+	}
+}
+
+func TestValidateConfigAcceptsKnownFields(t *testing.T) {
+	raw := []byte(`{
+  "flagSets": {"a": {"name": "A", "flags": ["--x"]}},
+  "browsers": [{"name": "Brave"}]
+}`)
+	issues, err := ValidateConfig(raw)
+	if err != nil {
+		t.Fatalf("ValidateConfig returned error: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("expected no issues, got %#v", issues)
+	}
+}
+
+func TestValidateConfigInvalidJSON(t *testing.T) {
+	if _, err := ValidateConfig([]byte(`{not json`)); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
+
+func TestValidateConfigTopLevelMustBeObject(t *testing.T) {
+	issues, err := ValidateConfig([]byte(`[1,2,3]`))
+	if err != nil {
+		t.Fatalf("ValidateConfig returned error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Path != "$" {
+		t.Errorf("expected a single top-level issue, got %#v", issues)
+	}
+}
+
+func TestValidateBrowsersFragmentRequiresName(t *testing.T) {
+	raw := []byte(`[{"description": "no name here"}]`)
+	issues, err := ValidateBrowsersFragment(raw)
+	if err != nil {
+		t.Fatalf("ValidateBrowsersFragment returned error: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d: %#v", len(issues), issues)
+	}
+	if issues[0].Message != `missing required field "name"` {
+		t.Errorf("Message = %q", issues[0].Message)
+	}
+}
+
+func TestValidateFlagSetsFragmentUnknownField(t *testing.T) {
+	raw := []byte(`{"custom": {"bogus": true}}`)
+	issues, err := ValidateFlagSetsFragment(raw)
+	if err != nil {
+		t.Fatalf("ValidateFlagSetsFragment returned error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Path != "$.custom.bogus" {
+		t.Errorf("expected one issue at $.custom.bogus, got %#v", issues)
+	}
+}
+
+func TestValidateConfigRejectsUnknownPortalPolicyEnumValue(t *testing.T) {
+	raw := []byte(`{"browsers": [{"name": "Brave", "portalPolicy": "bogus"}]}`)
+	issues, err := ValidateConfig(raw)
+	if err != nil {
+		t.Fatalf("ValidateConfig returned error: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d: %#v", len(issues), issues)
+	}
+	if issues[0].Path != "$.browsers[0].portalPolicy" {
+		t.Errorf("Path = %q, want %q", issues[0].Path, "$.browsers[0].portalPolicy")
+	}
+}
+
+func TestValidateConfigAcceptsKnownPortalPolicyEnumValue(t *testing.T) {
+	raw := []byte(`{"browsers": [{"name": "Brave", "portalPolicy": "prefer"}]}`)
+	issues, err := ValidateConfig(raw)
+	if err != nil {
+		t.Fatalf("ValidateConfig returned error: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("expected no issues, got %#v", issues)
+	}
+}
+
+// locate finds the *first* occurrence of a field's key text in the raw
+// document, so two unrelated objects that both misspell the same field
+// name are reported at the same line/column — a known limitation rather
+// than a true per-occurrence position.
+func TestLocateReportsFirstOccurrenceForDuplicateFieldNames(t *testing.T) {
+	raw := []byte(`{
+  "flagSets": {
+    "one": {"flagz": ["--a"]},
+    "two": {"flagz": ["--b"]}
+  }
+}`)
+	issues, err := ValidateConfig(raw)
+	if err != nil {
+		t.Fatalf("ValidateConfig returned error: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d: %#v", len(issues), issues)
+	}
+	for _, issue := range issues {
+		if issue.Line != 3 {
+			t.Errorf("issue %q reported at line %d, want 3 (first occurrence of \"flagz\")", issue.Path, issue.Line)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+// Package state tracks every mutation wailandify makes to a user's
+// .desktop files, so a bad flag change can be reverted and a user can see
+// whether a file is up-to-date, drifted (hand-edited since we last touched
+// it), or simply not tracked yet.
+package state
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Entry is one line of the state journal (state.jsonl).
+type Entry struct {
+	Timestamp   time.Time `json:"timestamp"`
+	Filename    string    `json:"filename"`
+	Action      string    `json:"action"` // "apply" or "revert"
+	PriorSHA256 string    `json:"priorSha256"`
+	NewSHA256   string    `json:"newSha256"`
+	FlagSets    []string  `json:"flagSets,omitempty"`
+	BackupPath  string    `json:"backupPath,omitempty"`
+}
+
+// Drift describes how a tracked file's current content compares to what the
+// journal last recorded for it.
+type Drift int
+
+const (
+	DriftUntracked Drift = iota // no journal entry for this file
+	DriftUpToDate               // current content matches our last write
+	DriftReverted               // current content matches what we last overwrote
+	DriftChanged                // current content matches neither — hand-edited
+)
+
+func (d Drift) String() string {
+	switch d {
+	case DriftUpToDate:
+		return "up-to-date"
+	case DriftReverted:
+		return "reverted"
+	case DriftChanged:
+		return "drifted"
+	default:
+		return "untracked"
+	}
+}
+
+// Journal records mutations under baseDir (backups/<timestamp>/<filename>
+// and state.jsonl) and restores from them on revert.
+type Journal struct {
+	baseDir string
+}
+
+// DefaultBaseDir returns ~/.local/state/wailandify (honoring $XDG_STATE_HOME).
+func DefaultBaseDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "wailandify"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine home directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "state", "wailandify"), nil
+}
+
+// Open ensures baseDir and its backups subdirectory exist.
+func Open(baseDir string) (*Journal, error) {
+	if err := os.MkdirAll(filepath.Join(baseDir, "backups"), 0755); err != nil {
+		return nil, fmt.Errorf("could not create state directory: %w", err)
+	}
+	return &Journal{baseDir: baseDir}, nil
+}
+
+func (j *Journal) journalPath() string {
+	return filepath.Join(j.baseDir, "state.jsonl")
+}
+
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// RecordApply saves prior to a timestamped backup directory and appends a
+// journal entry describing the mutation. Returns the entry it wrote.
+func (j *Journal) RecordApply(filename string, prior, next []byte, flagSets []string) (Entry, error) {
+	timestamp := time.Now().UTC()
+	backupDir := filepath.Join(j.baseDir, "backups", timestamp.Format("20060102T150405.000000000Z"))
+	if err := os.MkdirAll(backupDir, 0755); err != nil {
+		return Entry{}, fmt.Errorf("could not create backup directory: %w", err)
+	}
+	backupPath := filepath.Join(backupDir, filename)
+	if err := os.WriteFile(backupPath, prior, 0644); err != nil {
+		return Entry{}, fmt.Errorf("could not write backup for %s: %w", filename, err)
+	}
+
+	entry := Entry{
+		Timestamp:   timestamp,
+		Filename:    filename,
+		Action:      "apply",
+		PriorSHA256: sha256Hex(prior),
+		NewSHA256:   sha256Hex(next),
+		FlagSets:    flagSets,
+		BackupPath:  backupPath,
+	}
+	if err := j.append(entry); err != nil {
+		return Entry{}, err
+	}
+	return entry, nil
+}
+
+// RecordRevert appends a journal entry noting that filename was restored
+// from backupPath back to its pre-mutation content.
+func (j *Journal) RecordRevert(filename, backupPath string, restored []byte) error {
+	return j.append(Entry{
+		Timestamp:  time.Now().UTC(),
+		Filename:   filename,
+		Action:     "revert",
+		NewSHA256:  sha256Hex(restored),
+		BackupPath: backupPath,
+	})
+}
+
+func (j *Journal) append(entry Entry) error {
+	file, err := os.OpenFile(j.journalPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("could not open state journal: %w", err)
+	}
+	defer file.Close()
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("could not encode state entry: %w", err)
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("could not append to state journal: %w", err)
+	}
+	return nil
+}
+
+// Entries returns every journal entry in the order they were recorded.
+func (j *Journal) Entries() ([]Entry, error) {
+	file, err := os.Open(j.journalPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not read state journal: %w", err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf("could not parse state journal entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read state journal: %w", err)
+	}
+	return entries, nil
+}
+
+// LatestFor returns the most recent journal entry for filename, if any.
+func (j *Journal) LatestFor(filename string) (Entry, bool, error) {
+	entries, err := j.Entries()
+	if err != nil {
+		return Entry{}, false, err
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Filename == filename {
+			return entries[i], true, nil
+		}
+	}
+	return Entry{}, false, nil
+}
+
+// BeforeOrAt returns the most recent apply entry for filename at or before
+// cutoff, used by `revert --to=<timestamp>`.
+func (j *Journal) BeforeOrAt(filename string, cutoff time.Time) (Entry, bool, error) {
+	entries, err := j.Entries()
+	if err != nil {
+		return Entry{}, false, err
+	}
+	var best Entry
+	found := false
+	for _, entry := range entries {
+		if entry.Filename != filename || entry.Action != "apply" {
+			continue
+		}
+		if entry.Timestamp.After(cutoff) {
+			continue
+		}
+		if !found || entry.Timestamp.After(best.Timestamp) {
+			best = entry
+			found = true
+		}
+	}
+	return best, found, nil
+}
+
+// Classify compares a file's current on-disk content against the journal to
+// report whether it's untracked, up-to-date, reverted, or hand-edited since.
+func (j *Journal) Classify(filename string, current []byte) (Drift, Entry, error) {
+	entry, ok, err := j.LatestFor(filename)
+	if err != nil {
+		return DriftUntracked, Entry{}, err
+	}
+	if !ok {
+		return DriftUntracked, Entry{}, nil
+	}
+
+	currentSHA := sha256Hex(current)
+	switch currentSHA {
+	case entry.NewSHA256:
+		return DriftUpToDate, entry, nil
+	case entry.PriorSHA256:
+		return DriftReverted, entry, nil
+	default:
+		return DriftChanged, entry, nil
+	}
+}
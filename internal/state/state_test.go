@@ -0,0 +1,122 @@
+package state
+
+import (
+	"testing"
+	"time"
+)
+
+func openJournal(t *testing.T) *Journal {
+	t.Helper()
+	journal, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	return journal
+}
+
+func TestClassifyUntrackedFile(t *testing.T) {
+	journal := openJournal(t)
+
+	drift, _, err := journal.Classify("code.desktop", []byte("anything"))
+	if err != nil {
+		t.Fatalf("Classify returned error: %v", err)
+	}
+	if drift != DriftUntracked {
+		t.Errorf("Classify = %v, want %v", drift, DriftUntracked)
+	}
+}
+
+func TestClassifyUpToDateRevertedAndChanged(t *testing.T) {
+	journal := openJournal(t)
+
+	prior := []byte("Exec=/usr/bin/code %F\n")
+	next := []byte("Exec=/usr/bin/code --ozone-platform=wayland %F\n")
+	if _, err := journal.RecordApply("code.desktop", prior, next, []string{"wayland_basic"}); err != nil {
+		t.Fatalf("RecordApply returned error: %v", err)
+	}
+
+	drift, _, err := journal.Classify("code.desktop", next)
+	if err != nil {
+		t.Fatalf("Classify returned error: %v", err)
+	}
+	if drift != DriftUpToDate {
+		t.Errorf("Classify(next) = %v, want %v", drift, DriftUpToDate)
+	}
+
+	drift, _, err = journal.Classify("code.desktop", prior)
+	if err != nil {
+		t.Fatalf("Classify returned error: %v", err)
+	}
+	if drift != DriftReverted {
+		t.Errorf("Classify(prior) = %v, want %v", drift, DriftReverted)
+	}
+
+	drift, _, err = journal.Classify("code.desktop", []byte("something else entirely"))
+	if err != nil {
+		t.Fatalf("Classify returned error: %v", err)
+	}
+	if drift != DriftChanged {
+		t.Errorf("Classify(hand-edited) = %v, want %v", drift, DriftChanged)
+	}
+}
+
+func TestBeforeOrAtReturnsMostRecentApplyAtOrBeforeCutoff(t *testing.T) {
+	journal := openJournal(t)
+
+	older := Entry{Timestamp: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Filename: "code.desktop", Action: "apply", NewSHA256: "old"}
+	newer := Entry{Timestamp: time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC), Filename: "code.desktop", Action: "apply", NewSHA256: "new"}
+	future := Entry{Timestamp: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), Filename: "code.desktop", Action: "apply", NewSHA256: "future"}
+	for _, entry := range []Entry{older, newer, future} {
+		if err := journal.append(entry); err != nil {
+			t.Fatalf("append returned error: %v", err)
+		}
+	}
+
+	cutoff := time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC)
+	got, found, err := journal.BeforeOrAt("code.desktop", cutoff)
+	if err != nil {
+		t.Fatalf("BeforeOrAt returned error: %v", err)
+	}
+	if !found {
+		t.Fatal("expected an entry to be found")
+	}
+	if got.NewSHA256 != "new" {
+		t.Errorf("BeforeOrAt = %q, want %q", got.NewSHA256, "new")
+	}
+}
+
+func TestBeforeOrAtIgnoresOtherFilenamesAndRevertEntries(t *testing.T) {
+	journal := openJournal(t)
+
+	sameTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := journal.append(Entry{Timestamp: sameTime, Filename: "brave.desktop", Action: "apply", NewSHA256: "other-file"}); err != nil {
+		t.Fatalf("append returned error: %v", err)
+	}
+	if err := journal.append(Entry{Timestamp: sameTime, Filename: "code.desktop", Action: "revert", NewSHA256: "a-revert"}); err != nil {
+		t.Fatalf("append returned error: %v", err)
+	}
+
+	_, found, err := journal.BeforeOrAt("code.desktop", sameTime)
+	if err != nil {
+		t.Fatalf("BeforeOrAt returned error: %v", err)
+	}
+	if found {
+		t.Error("expected no apply entry to be found for code.desktop")
+	}
+}
+
+func TestBeforeOrAtNoEntryBeforeCutoff(t *testing.T) {
+	journal := openJournal(t)
+
+	if _, err := journal.RecordApply("code.desktop", []byte("a"), []byte("b"), nil); err != nil {
+		t.Fatalf("RecordApply returned error: %v", err)
+	}
+
+	_, found, err := journal.BeforeOrAt("code.desktop", time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("BeforeOrAt returned error: %v", err)
+	}
+	if found {
+		t.Error("expected no entry before a cutoff that predates every apply")
+	}
+}